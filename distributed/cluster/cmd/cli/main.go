@@ -1,17 +1,25 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"hash"
+	"io"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/uttom-akash/vector-xlite/distributed/cluster/pkg/client"
 	"github.com/uttom-akash/vector-xlite/distributed/cluster/pkg/pb"
+	types "github.com/uttom-akash/vector-xlite/go_grpc_client/types"
 )
 
 func main() {
@@ -28,6 +36,8 @@ func main() {
 		createCollectionCmd()
 	case "insert":
 		insertCmd()
+	case "bulk-insert":
+		bulkInsertCmd()
 	case "search":
 		searchCmd()
 	case "delete":
@@ -36,8 +46,18 @@ func main() {
 		deleteCollectionCmd()
 	case "join":
 		joinCmd()
+	case "promote":
+		promoteCmd()
+	case "demote":
+		demoteCmd()
+	case "transfer-leadership":
+		transferLeadershipCmd()
+	case "drain":
+		drainCmd()
 	case "info":
 		infoCmd()
+	case "snapshot":
+		snapshotCmd()
 	default:
 		fmt.Printf("Unknown command: %s\n", subcommand)
 		printUsage()
@@ -45,6 +65,43 @@ func main() {
 	}
 }
 
+// authFlags holds the -tls-cert/-tls-key/-tls-ca/-token values every
+// subcommand's FlagSet registers via registerAuthFlags, so vxctl can talk
+// to a cluster with TLS/mTLS and/or token auth enabled.
+type authFlags struct {
+	tlsCert string
+	tlsKey  string
+	tlsCA   string
+	token   string
+}
+
+// registerAuthFlags adds the -tls-cert/-tls-key/-tls-ca/-token flags to
+// fs, returning a struct populated once fs.Parse runs.
+func registerAuthFlags(fs *flag.FlagSet) *authFlags {
+	a := &authFlags{}
+	fs.StringVar(&a.tlsCert, "tls-cert", "", "Client certificate file for mTLS (requires -tls-key)")
+	fs.StringVar(&a.tlsKey, "tls-key", "", "Client private key file for mTLS (requires -tls-cert)")
+	fs.StringVar(&a.tlsCA, "tls-ca", "", "CA certificate file to verify the server (enables TLS)")
+	fs.StringVar(&a.token, "token", "", "Bearer token for auth-enabled clusters")
+	return a
+}
+
+// newClusterClient connects to addr with a's TLS/token settings applied,
+// the auth-aware counterpart to client.NewClusterClientSimple every
+// subcommand in this file used before -tls-cert/-tls-key/-tls-ca/-token
+// existed.
+func newClusterClient(addr string, a *authFlags) (*client.ClusterClient, error) {
+	return client.NewClusterClient(client.ClientConfig{
+		Addr:           addr,
+		MaxRedirects:   3,
+		ConnectTimeout: 5 * time.Second,
+		TLSCertFile:    a.tlsCert,
+		TLSKeyFile:     a.tlsKey,
+		TLSCAFile:      a.tlsCA,
+		AuthToken:      a.token,
+	})
+}
+
 func printUsage() {
 	fmt.Println("VectorXLite Proxy Client CLI")
 	fmt.Println()
@@ -54,20 +111,36 @@ func printUsage() {
 	fmt.Println("Commands:")
 	fmt.Println("  create-collection  Create a new vector collection")
 	fmt.Println("  insert             Insert a vector into a collection")
+	fmt.Println("  bulk-insert        Stream a CSV/JSONL file of vectors into a collection")
 	fmt.Println("  search             Search for similar vectors")
 	fmt.Println("  delete             Delete a vector from a collection")
 	fmt.Println("  delete-collection  Delete a collection")
 	fmt.Println("  join               Join a node to the cluster")
+	fmt.Println("  promote            Promote a caught-up learner to a voter")
+	fmt.Println("  demote             Demote a voter and remove it from the cluster")
+	fmt.Println("  transfer-leadership  Hand off leader role to another voter without removing the current leader")
+	fmt.Println("  drain              Drain a node before a rolling restart/eviction: transfer leadership away, then leave the cluster")
 	fmt.Println("  info               Get cluster information")
+	fmt.Println("  snapshot inspect   Validate a snapshot file offline, without touching a live node")
+	fmt.Println("  snapshot backup    Ask the leader to write a backup to a path on its own filesystem")
+	fmt.Println("  snapshot restore   Ask the leader to restore its FSM from a path on its own filesystem")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  client create-collection -addr :5002 -name users -dim 128 -schema \"create table users(rowid integer primary key, name text)\"")
 	fmt.Println("  client insert -addr :5002 -name users -id 1 -vector \"1.0,2.0,3.0\" -query \"insert into users(name) values ('Alice')\"")
+	fmt.Println("  client bulk-insert -addr :5002 -name users -file points.jsonl -batch 500 -parallel 4")
 	fmt.Println("  client search -addr :5002 -name users -vector \"1.0,2.0,3.0\" -k 5 -query \"select rowid, name from users\"")
+	fmt.Println("  client search -addr :5002 -name users -vector \"1.0,2.0,3.0\" -k 5 -consistency linearizable")
 	fmt.Println("  client delete -addr :5002 -name users -id 1")
 	fmt.Println("  client delete-collection -addr :5002 -name users")
 	fmt.Println("  client join -addr :5002 -node-id node2 -node-addr 127.0.0.1:5021")
+	fmt.Println("  client join -addr :5002 -node-id node2 -node-addr 127.0.0.1:5021 -learner")
+	fmt.Println("  client promote -addr :5002 -node-id node2 -node-addr 127.0.0.1:5021 -max-lag 1000")
+	fmt.Println("  client demote -addr :5002 -node-id node2")
+	fmt.Println("  client transfer-leadership -addr :5002 -node-id node2")
+	fmt.Println("  client drain -addr :5002  (run against the node being evicted, not the leader)")
 	fmt.Println("  client info -addr :5002")
+	fmt.Println("  client info -addr :5002 -tls-ca ca.pem -token s3cr3t  (every command accepts -tls-cert/-tls-key/-tls-ca/-token for an auth-enabled cluster)")
 }
 
 func createCollectionCmd() {
@@ -76,6 +149,7 @@ func createCollectionCmd() {
 	name := fs.String("name", "", "Collection name")
 	dim := fs.Int("dim", 128, "Vector dimension")
 	schema := fs.String("schema", "", "Payload table schema (SQL CREATE TABLE)")
+	auth := registerAuthFlags(fs)
 
 	fs.Parse(os.Args[2:])
 
@@ -83,7 +157,7 @@ func createCollectionCmd() {
 		log.Fatal("Collection name and schema are required")
 	}
 
-	clusterClient, err := client.NewClusterClientSimple(*addr)
+	clusterClient, err := newClusterClient(*addr, auth)
 	if err != nil {
 		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
 	}
@@ -112,6 +186,7 @@ func insertCmd() {
 	id := fs.Int64("id", 0, "Vector ID")
 	vectorStr := fs.String("vector", "", "Vector values (comma-separated floats)")
 	query := fs.String("query", "", "Payload insert SQL query")
+	auth := registerAuthFlags(fs)
 
 	fs.Parse(os.Args[2:])
 
@@ -130,7 +205,7 @@ func insertCmd() {
 		vector[i] = float32(val)
 	}
 
-	clusterClient, err := client.NewClusterClientSimple(*addr)
+	clusterClient, err := newClusterClient(*addr, auth)
 	if err != nil {
 		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
 	}
@@ -152,6 +227,178 @@ func insertCmd() {
 	fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
 }
 
+// bulkInsertRow is one line of a JSONL bulk-insert file.
+type bulkInsertRow struct {
+	Id     int64     `json:"id"`
+	Vector []float32 `json:"vector"`
+	Query  string    `json:"query"`
+}
+
+func bulkInsertCmd() {
+	fs := flag.NewFlagSet("bulk-insert", flag.ExitOnError)
+	addr := fs.String("addr", ":5002", "Cluster server address")
+	name := fs.String("name", "", "Collection name")
+	file := fs.String("file", "", "CSV or JSONL file of rows to insert (.csv: id,vector(;-separated),query; .jsonl: {\"id\":..,\"vector\":[..],\"query\":\"..\"})")
+	batch := fs.Int("batch", 500, "Rows per BulkInsert stream call")
+	parallel := fs.Int("parallel", 1, "Number of concurrent BulkInsert streams")
+	auth := registerAuthFlags(fs)
+
+	fs.Parse(os.Args[2:])
+
+	if *name == "" || *file == "" {
+		log.Fatal("Collection name and file are required")
+	}
+	if *batch <= 0 {
+		*batch = 500
+	}
+	if *parallel <= 0 {
+		*parallel = 1
+	}
+
+	rows, err := readBulkInsertFile(*file, *name)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *file, err)
+	}
+	if len(rows) == 0 {
+		log.Fatal("No rows found in file")
+	}
+
+	clusterClient, err := newClusterClient(*addr, auth)
+	if err != nil {
+		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
+	}
+	defer clusterClient.Close()
+
+	batches := make([][]*pb.InsertRequest, 0, (len(rows)+*batch-1)/(*batch))
+	for start := 0; start < len(rows); start += *batch {
+		end := start + *batch
+		if end > len(rows) {
+			end = len(rows)
+		}
+		batches = append(batches, rows[start:end])
+	}
+
+	type batchOutcome struct {
+		inserted, failed int64
+		err              error
+	}
+
+	gate := make(chan struct{}, *parallel)
+	outcomes := make([]batchOutcome, len(batches))
+	var wg sync.WaitGroup
+
+	started := time.Now()
+	for i, b := range batches {
+		wg.Add(1)
+		gate <- struct{}{}
+		go func(idx int, reqs []*pb.InsertRequest) {
+			defer wg.Done()
+			defer func() { <-gate }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+
+			resp, err := clusterClient.BulkInsert(ctx, reqs)
+			if err != nil {
+				outcomes[idx] = batchOutcome{failed: int64(len(reqs)), err: err}
+				return
+			}
+			outcomes[idx] = batchOutcome{inserted: resp.Inserted, failed: resp.Failed}
+		}(i, b)
+	}
+	wg.Wait()
+	elapsed := time.Since(started)
+
+	var totalInserted, totalFailed int64
+	for i, o := range outcomes {
+		totalInserted += o.inserted
+		totalFailed += o.failed
+		if o.err != nil {
+			fmt.Printf("batch %d: failed to apply: %v\n", i, o.err)
+		}
+	}
+
+	fmt.Printf("Inserted: %d, Failed: %d, Rows: %d\n", totalInserted, totalFailed, len(rows))
+	fmt.Printf("Elapsed: %s, Throughput: %.1f rows/sec\n", elapsed, float64(len(rows))/elapsed.Seconds())
+}
+
+// readBulkInsertFile reads rows to insert into collectionName from path,
+// dispatching on its extension: ".jsonl" for one bulkInsertRow per line,
+// anything else (".csv" by convention) for "id,vector;-separated,query".
+func readBulkInsertFile(path, collectionName string) ([]*pb.InsertRequest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	jsonl := strings.HasSuffix(strings.ToLower(path), ".jsonl")
+
+	var reqs []*pb.InsertRequest
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var row bulkInsertRow
+		if jsonl {
+			if err := json.Unmarshal([]byte(line), &row); err != nil {
+				return nil, fmt.Errorf("parse jsonl row: %w", err)
+			}
+		} else {
+			fields := strings.SplitN(line, ",", 3)
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("csv row %q: want id,vector,query", line)
+			}
+			id, err := strconv.ParseInt(strings.TrimSpace(fields[0]), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("csv row %q: invalid id: %w", line, err)
+			}
+			vectorParts := strings.Split(fields[1], ";")
+			vector := make([]float32, len(vectorParts))
+			for i, v := range vectorParts {
+				val, err := strconv.ParseFloat(strings.TrimSpace(v), 32)
+				if err != nil {
+					return nil, fmt.Errorf("csv row %q: invalid vector value %q: %w", line, v, err)
+				}
+				vector[i] = float32(val)
+			}
+			row = bulkInsertRow{Id: id, Vector: vector, Query: strings.TrimSpace(fields[2])}
+		}
+
+		reqs = append(reqs, &pb.InsertRequest{
+			CollectionName:     collectionName,
+			Id:                 row.Id,
+			Vector:             row.Vector,
+			PayloadInsertQuery: row.Query,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+// parseConsistency maps the -consistency CLI flag's lowercase, hyphenated
+// spelling to the string ClusterServer.Search's switch matches on.
+func parseConsistency(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "", "eventual":
+		return "Eventual", nil
+	case "bounded-staleness", "bounded_staleness", "boundedstaleness":
+		return "BoundedStaleness", nil
+	case "linearizable":
+		return "Linearizable", nil
+	case "leader-only", "leader_only", "leaderonly", "leader", "strong":
+		return "LeaderOnly", nil
+	default:
+		return "", fmt.Errorf("unknown -consistency %q, want eventual, bounded-staleness, linearizable, or leader-only", s)
+	}
+}
+
 func searchCmd() {
 	fs := flag.NewFlagSet("search", flag.ExitOnError)
 	addr := fs.String("addr", ":5002", "Cluster server address")
@@ -159,6 +406,9 @@ func searchCmd() {
 	vectorStr := fs.String("vector", "", "Query vector (comma-separated floats)")
 	k := fs.Int("k", 5, "Number of results (top-K)")
 	query := fs.String("query", "", "Payload search SQL query")
+	consistency := fs.String("consistency", "eventual", "Read consistency: eventual, bounded-staleness, linearizable, or leader-only (strong)")
+	maxLagMs := fs.Int64("max-lag-ms", 0, "Max staleness in ms for -consistency bounded-staleness")
+	auth := registerAuthFlags(fs)
 
 	fs.Parse(os.Args[2:])
 
@@ -166,6 +416,11 @@ func searchCmd() {
 		log.Fatal("Collection name and vector are required")
 	}
 
+	pbConsistency, err := parseConsistency(*consistency)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Parse vector
 	vectorParts := strings.Split(*vectorStr, ",")
 	vector := make([]float32, len(vectorParts))
@@ -177,7 +432,7 @@ func searchCmd() {
 		vector[i] = float32(val)
 	}
 
-	clusterClient, err := client.NewClusterClientSimple(*addr)
+	clusterClient, err := newClusterClient(*addr, auth)
 	if err != nil {
 		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
 	}
@@ -191,6 +446,8 @@ func searchCmd() {
 		Vector:             vector,
 		TopK:               uint32(*k),
 		PayloadSearchQuery: *query,
+		Consistency:        pbConsistency,
+		MaxLagMs:           *maxLagMs,
 	})
 	if err != nil {
 		log.Fatalf("Failed to search: %v", err)
@@ -213,6 +470,7 @@ func deleteCmd() {
 	addr := fs.String("addr", ":5002", "Cluster server address")
 	name := fs.String("name", "", "Collection name")
 	id := fs.Int64("id", 0, "Vector ID to delete")
+	auth := registerAuthFlags(fs)
 
 	fs.Parse(os.Args[2:])
 
@@ -220,7 +478,7 @@ func deleteCmd() {
 		log.Fatal("Collection name and id are required")
 	}
 
-	clusterClient, err := client.NewClusterClientSimple(*addr)
+	clusterClient, err := newClusterClient(*addr, auth)
 	if err != nil {
 		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
 	}
@@ -244,6 +502,7 @@ func deleteCollectionCmd() {
 	fs := flag.NewFlagSet("delete-collection", flag.ExitOnError)
 	addr := fs.String("addr", ":5002", "Cluster server address")
 	name := fs.String("name", "", "Collection name to delete")
+	auth := registerAuthFlags(fs)
 
 	fs.Parse(os.Args[2:])
 
@@ -251,7 +510,7 @@ func deleteCollectionCmd() {
 		log.Fatal("Collection name is required")
 	}
 
-	clusterClient, err := client.NewClusterClientSimple(*addr)
+	clusterClient, err := newClusterClient(*addr, auth)
 	if err != nil {
 		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
 	}
@@ -270,11 +529,19 @@ func deleteCollectionCmd() {
 	fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
 }
 
+// joinProtocolVersion is the ProtocolVersion this CLI advertises when
+// joining a cluster; it must match the consensus package's own
+// CurrentProtocolVersion, since vxctl is built and shipped alongside the
+// node binary rather than linking against it.
+const joinProtocolVersion = 1
+
 func joinCmd() {
 	fs := flag.NewFlagSet("join", flag.ExitOnError)
 	addr := fs.String("addr", ":5002", "Cluster server address")
 	nodeID := fs.String("node-id", "", "New node ID to join")
 	nodeAddr := fs.String("node-addr", "", "New node raft address (e.g., 127.0.0.1:5021)")
+	learner := fs.Bool("learner", false, "Join as a non-voting learner instead of a full voter")
+	auth := registerAuthFlags(fs)
 
 	fs.Parse(os.Args[2:])
 
@@ -282,7 +549,7 @@ func joinCmd() {
 		log.Fatal("Node ID and node address are required")
 	}
 
-	clusterClient, err := client.NewClusterClientSimple(*addr)
+	clusterClient, err := newClusterClient(*addr, auth)
 	if err != nil {
 		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
 	}
@@ -291,7 +558,7 @@ func joinCmd() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	resp, err := clusterClient.JoinCluster(ctx, *nodeID, *nodeAddr)
+	resp, err := clusterClient.JoinCluster(ctx, *nodeID, *nodeAddr, *learner, joinProtocolVersion, nil)
 	if err != nil {
 		log.Fatalf("Failed to join node: %v", err)
 	}
@@ -299,13 +566,156 @@ func joinCmd() {
 	fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
 }
 
+// promoteCmd reads the learner's own applied index directly off the
+// learner (via NodeStatus), then asks the leader to promote it, leaving
+// the lag check to PromoteCluster rather than trusting a possibly-stale
+// value from elsewhere.
+func promoteCmd() {
+	fs := flag.NewFlagSet("promote", flag.ExitOnError)
+	addr := fs.String("addr", ":5002", "Cluster server address (any member)")
+	nodeID := fs.String("node-id", "", "Learner node ID to promote")
+	nodeAddr := fs.String("node-addr", "", "Learner node raft address (e.g., 127.0.0.1:5021)")
+	learnerAddr := fs.String("learner-addr", "", "Learner node cluster address to read its applied index from (default: node-addr with port+1)")
+	maxLag := fs.Uint64("max-lag", 1000, "Max log entries the learner may be behind the leader to be promoted")
+	auth := registerAuthFlags(fs)
+
+	fs.Parse(os.Args[2:])
+
+	if *nodeID == "" || *nodeAddr == "" {
+		log.Fatal("Node ID and node address are required")
+	}
+	statusAddr := *learnerAddr
+	if statusAddr == "" {
+		colon := strings.LastIndex(*nodeAddr, ":")
+		port, err := strconv.Atoi((*nodeAddr)[colon+1:])
+		if colon == -1 || err != nil {
+			log.Fatalf("Invalid node address %q: want host:port", *nodeAddr)
+		}
+		statusAddr = (*nodeAddr)[:colon+1] + strconv.Itoa(port+1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	learnerClient, err := newClusterClient(statusAddr, auth)
+	if err != nil {
+		log.Fatalf("Failed to connect to learner at %s: %v", statusAddr, err)
+	}
+	nodeStatus, err := learnerClient.NodeStatus(ctx)
+	learnerClient.Close()
+	if err != nil {
+		log.Fatalf("Failed to get learner status: %v", err)
+	}
+
+	clusterClient, err := newClusterClient(*addr, auth)
+	if err != nil {
+		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
+	}
+	defer clusterClient.Close()
+
+	resp, err := clusterClient.PromoteCluster(ctx, *nodeID, *nodeAddr, nodeStatus.AppliedIndex, *maxLag)
+	if err != nil {
+		log.Fatalf("Failed to promote node: %v", err)
+	}
+
+	fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+}
+
+func demoteCmd() {
+	fs := flag.NewFlagSet("demote", flag.ExitOnError)
+	addr := fs.String("addr", ":5002", "Cluster server address")
+	nodeID := fs.String("node-id", "", "Voter node ID to demote and remove")
+	auth := registerAuthFlags(fs)
+
+	fs.Parse(os.Args[2:])
+
+	if *nodeID == "" {
+		log.Fatal("Node ID is required")
+	}
+
+	clusterClient, err := newClusterClient(*addr, auth)
+	if err != nil {
+		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
+	}
+	defer clusterClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := clusterClient.DemoteCluster(ctx, *nodeID)
+	if err != nil {
+		log.Fatalf("Failed to demote node: %v", err)
+	}
+
+	fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+}
+
+func transferLeadershipCmd() {
+	fs := flag.NewFlagSet("transfer-leadership", flag.ExitOnError)
+	addr := fs.String("addr", ":5002", "Cluster server address (must be current leader)")
+	nodeID := fs.String("node-id", "", "Voter node ID to hand leadership to")
+	nodeAddr := fs.String("node-addr", "", "Node raft address (default: resolved from cluster configuration)")
+	auth := registerAuthFlags(fs)
+
+	fs.Parse(os.Args[2:])
+
+	if *nodeID == "" {
+		log.Fatal("Node ID is required")
+	}
+
+	clusterClient, err := newClusterClient(*addr, auth)
+	if err != nil {
+		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
+	}
+	defer clusterClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := clusterClient.TransferLeadership(ctx, *nodeID, *nodeAddr)
+	if err != nil {
+		log.Fatalf("Failed to transfer leadership: %v", err)
+	}
+
+	fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+}
+
+// drainCmd connects directly to the node being evicted (-addr must be
+// that node's own cluster address, not just any member) and asks it to
+// drain: transfer away leadership if it holds it, then remove itself from
+// the cluster, the safe sequence for a rolling restart or pod eviction.
+func drainCmd() {
+	fs := flag.NewFlagSet("drain", flag.ExitOnError)
+	addr := fs.String("addr", ":5002", "Cluster address of the node to drain (not any member - this one specifically)")
+	auth := registerAuthFlags(fs)
+
+	fs.Parse(os.Args[2:])
+
+	clusterClient, err := newClusterClient(*addr, auth)
+	if err != nil {
+		log.Fatalf("Failed to connect to node at %s: %v", *addr, err)
+	}
+	defer clusterClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := clusterClient.Drain(ctx)
+	if err != nil {
+		log.Fatalf("Failed to drain node: %v", err)
+	}
+
+	fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+}
+
 func infoCmd() {
 	fs := flag.NewFlagSet("info", flag.ExitOnError)
 	addr := fs.String("addr", ":5002", "Cluster server address")
+	auth := registerAuthFlags(fs)
 
 	fs.Parse(os.Args[2:])
 
-	clusterClient, err := client.NewClusterClientSimple(*addr)
+	clusterClient, err := newClusterClient(*addr, auth)
 	if err != nil {
 		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
 	}
@@ -324,6 +734,196 @@ func infoCmd() {
 	fmt.Printf("Node State: %s\n", info.State)
 	fmt.Printf("\nCluster Nodes (%d):\n", len(info.Nodes))
 	for _, node := range info.Nodes {
-		fmt.Printf("  - %s (%s): %s, Voter: %v\n", node.NodeId, node.Addr, node.State, node.IsVoter)
+		fmt.Printf("  - %s (%s): %s, %s\n", node.NodeId, node.Addr, node.State, node.Suffrage)
+	}
+
+	if info.MinCapabilities != nil {
+		fmt.Printf("\nCluster-wide min capabilities:\n")
+		fmt.Printf("  Protocol version: %d\n", info.MinCapabilities.ProtocolVersion)
+		for feature, enabled := range info.MinCapabilities.Features {
+			if enabled {
+				fmt.Printf("  - %s\n", feature)
+			}
+		}
+	}
+}
+
+func snapshotCmd() {
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: client snapshot inspect <file> | backup <dest> | restore <src>")
+		os.Exit(1)
+	}
+
+	switch os.Args[2] {
+	case "inspect":
+		snapshotInspectCmd()
+	case "backup":
+		snapshotBackupCmd()
+	case "restore":
+		snapshotRestoreCmd()
+	default:
+		fmt.Printf("Unknown snapshot subcommand: %s\n", os.Args[2])
+		os.Exit(1)
+	}
+}
+
+// snapshotBackupCmd asks the leader to write a backup to a path or URL on
+// its own filesystem via ClusterServer.CreateBackup, unlike "snapshot
+// inspect" which only ever reads a file already on this machine.
+func snapshotBackupCmd() {
+	fs := flag.NewFlagSet("snapshot backup", flag.ExitOnError)
+	addr := fs.String("addr", ":5002", "Cluster server address")
+	auth := registerAuthFlags(fs)
+	fs.Parse(os.Args[3:])
+
+	if fs.NArg() < 1 {
+		log.Fatal("backup destination is required: client snapshot backup <dest>")
+	}
+	dest := fs.Arg(0)
+
+	clusterClient, err := newClusterClient(*addr, auth)
+	if err != nil {
+		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
+	}
+	defer clusterClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	resp, err := clusterClient.CreateBackup(ctx, dest)
+	if err != nil {
+		log.Fatalf("Failed to create backup: %v", err)
+	}
+	fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+}
+
+// snapshotRestoreCmd asks the leader to restore its FSM from a path or URL
+// on its own filesystem via ClusterServer.RestoreBackup.
+func snapshotRestoreCmd() {
+	fs := flag.NewFlagSet("snapshot restore", flag.ExitOnError)
+	addr := fs.String("addr", ":5002", "Cluster server address")
+	auth := registerAuthFlags(fs)
+	fs.Parse(os.Args[3:])
+
+	if fs.NArg() < 1 {
+		log.Fatal("restore source is required: client snapshot restore <src>")
+	}
+	src := fs.Arg(0)
+
+	clusterClient, err := newClusterClient(*addr, auth)
+	if err != nil {
+		log.Fatalf("Failed to connect to cluster at %s: %v", *addr, err)
+	}
+	defer clusterClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	resp, err := clusterClient.RestoreBackup(ctx, src)
+	if err != nil {
+		log.Fatalf("Failed to restore backup: %v", err)
+	}
+	fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+}
+
+// snapshotFileStat accumulates per-file stats while reading a snapshot
+// file, so snapshotInspectCmd can report computed vs. stored checksums
+// without holding file data in memory.
+type snapshotFileStat struct {
+	fileType  types.SnapshotFileType
+	size      uint64
+	storedSum string
+	chunks    int
+	hasher    hash.Hash
+}
+
+// snapshotInspectCmd reads a length-prefixed, checksummed snapshot file
+// produced by ClusterClient.SnapshotSave and reports per-file size, type,
+// chunk count, and computed-vs-stored checksums, plus overall metadata.
+// It never contacts a live node.
+func snapshotInspectCmd() {
+	fs := flag.NewFlagSet("snapshot inspect", flag.ExitOnError)
+	fs.Parse(os.Args[3:])
+
+	if fs.NArg() < 1 {
+		log.Fatal("snapshot file path is required: client snapshot inspect <file>")
+	}
+	path := fs.Arg(0)
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	stats := make(map[string]*snapshotFileStat)
+	order := make([]string, 0)
+	var meta *types.SnapshotMetadata
+
+	statFor := func(name string) *snapshotFileStat {
+		st, ok := stats[name]
+		if !ok {
+			st = &snapshotFileStat{hasher: sha256.New()}
+			stats[name] = st
+			order = append(order, name)
+		}
+		return st
+	}
+
+	for {
+		chunk, err := client.ReadSnapshotChunk(f)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Failed to read chunk: %v", err)
+		}
+
+		if chunk.Metadata != nil {
+			meta = chunk.Metadata
+			for _, fi := range meta.Files {
+				st := statFor(fi.FileName)
+				st.fileType = fi.FileType
+				st.size = fi.FileSize
+				st.storedSum = fi.Checksum
+			}
+		}
+
+		if fc := chunk.FileChunk; fc != nil {
+			st := statFor(fc.FileName)
+			st.chunks++
+			st.hasher.Write(fc.Data)
+		}
+
+		if chunk.IsFinal {
+			break
+		}
+	}
+
+	if meta == nil {
+		log.Fatal("snapshot file has no metadata; is this a valid snapshot?")
+	}
+
+	fmt.Printf("Snapshot ID: %s\n", meta.SnapshotID)
+	fmt.Printf("Created At:  %d\n", meta.CreatedAt)
+	fmt.Printf("Version:     %d\n", meta.Version)
+	fmt.Printf("Checksum:    %s\n", meta.Checksum)
+	fmt.Printf("\nFiles (%d):\n", len(order))
+
+	mismatches := 0
+	for _, name := range order {
+		st := stats[name]
+		computed := hex.EncodeToString(st.hasher.Sum(nil))
+		status := "OK"
+		if st.storedSum != "" && computed != st.storedSum {
+			status = "MISMATCH"
+			mismatches++
+		}
+		fmt.Printf("  - %s: type=%s size=%d chunks=%d stored=%s computed=%s [%s]\n",
+			name, st.fileType, st.size, st.chunks, st.storedSum, computed, status)
+	}
+
+	if mismatches > 0 {
+		log.Fatalf("%d file(s) failed checksum verification", mismatches)
 	}
 }
@@ -3,21 +3,32 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"math"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	types "github.com/uttom-akash/vector-xlite/go_grpc_client/types"
 	"github.com/uttom-akash/vector-xlite/vector_xlite_proxy/pkg/consensus"
+	"github.com/uttom-akash/vector-xlite/vector_xlite_proxy/pkg/metrics"
 	"github.com/uttom-akash/vector-xlite/vector_xlite_proxy/pkg/pb"
 	"github.com/uttom-akash/vector-xlite/vector_xlite_proxy/pkg/server"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 func main() {
@@ -27,6 +38,19 @@ func main() {
 	vectorAddr := flag.String("vector-addr", "0.0.0.0:50051", "VectorXLite gRPC server address")
 	dataDir := flag.String("data-dir", "./data", "Data directory for raft logs")
 	bootstrap := flag.Bool("bootstrap", false, "Bootstrap as first node in cluster")
+	snapshotInterval := flag.Duration("snapshot-interval", 0, "How often to check whether a Raft snapshot is due (0 = raft default)")
+	snapshotThreshold := flag.Uint64("snapshot-threshold", 0, "Log entries since the last snapshot before Raft triggers one (0 = raft default)")
+	trailingLogs := flag.Uint64("trailing-logs", 0, "Log entries Raft retains after a snapshot, for follower catch-up without InstallSnapshot (0 = raft default)")
+	join := flag.String("join", "", "Comma-separated seed cluster gRPC addresses (host:port) to discover the leader and request voter membership from, e.g. existing-node:5002")
+	peers := flag.String("peers", "", "Comma-separated id=raftAddr voters to bootstrap a fresh cluster with in one shot, e.g. node1=127.0.0.1:5001,node2=127.0.0.1:5011 (only used with -bootstrap)")
+	maxCommandBytes := flag.Int64("max-command-bytes", 0, "Max serialized size of an Insert/InsertBatch Raft command before it's split into CmdChunk fragments (0 = consensus package default)")
+	logStore := flag.String("log-store", "bolt", "Raft log store engine: \"bolt\" (default, mmap'd single-file) or \"wal\" (segmented-file, cheaper truncation at scale)")
+	metricsAddr := flag.String("metrics-addr", "", "Address to serve Prometheus /metrics on, e.g. :9091 (empty disables it)")
+	tlsCert := flag.String("tls-cert", "", "Server TLS certificate file (enables TLS; requires -tls-key)")
+	tlsKey := flag.String("tls-key", "", "Server TLS private key file (requires -tls-cert)")
+	tlsCA := flag.String("tls-ca", "", "CA certificate file to verify client certificates against (enables mTLS)")
+	authPolicyFile := flag.String("auth-policy", "", "Path to a JSON file mapping RPC method to required role (admin/writer/reader); empty uses the built-in default policy")
+	authTokensFile := flag.String("auth-tokens", "", "Path to a JSON file mapping bearer token to required role; empty disables token auth")
 
 	flag.Parse()
 
@@ -55,15 +79,316 @@ func main() {
 		log.Fatalf("Failed to create data directory: %v", err)
 	}
 
+	var logStoreFactory consensus.LogStoreFactory
+	switch *logStore {
+	case "", "bolt":
+		logStoreFactory = consensus.BoltLogStoreFactory{}
+	case "wal":
+		logStoreFactory = consensus.WALLogStoreFactory{}
+	default:
+		log.Fatalf("Unknown -log-store %q, want \"bolt\" or \"wal\"", *logStore)
+	}
+
+	// With -peers given, bootstrap the full initial voter set in one shot
+	// instead of -bootstrap's single-self-voter config followed by manual
+	// AddVoter calls, so NewRaftNode must not also self-bootstrap.
+	peerList, err := parsePeers(*peers)
+	if err != nil {
+		log.Fatalf("Failed to parse -peers: %v", err)
+	}
+	selfBootstrap := *bootstrap && len(peerList) == 0
+
 	// Create raft node
-	vxRaftNode, err := consensus.NewRaftNode(*nodeID, raftAddr, *vectorAddr, nodeDataDir, *bootstrap)
+	vxRaftNode, err := consensus.NewRaftNode(*nodeID, raftAddr, *vectorAddr, nodeDataDir, selfBootstrap, consensus.RaftNodeOptions{
+		SnapshotInterval:  *snapshotInterval,
+		SnapshotThreshold: *snapshotThreshold,
+		TrailingLogs:      *trailingLogs,
+		LogStoreFactory:   logStoreFactory,
+	})
 	if err != nil {
 		log.Fatalf("Failed to create raft node: %v", err)
 	}
 
+	if *bootstrap && len(peerList) > 0 {
+		if err := vxRaftNode.BootstrapCluster(peerList); err != nil {
+			log.Fatalf("Failed to bootstrap cluster with -peers: %v", err)
+		}
+	}
+
+	// metricsRegistry is node-local rather than prometheus.DefaultRegisterer,
+	// so multiple nodes sharing one process (as the integration tests do)
+	// don't panic on duplicate collector registration.
+	metricsRegistry := prometheus.NewRegistry()
+	metricsCollectors := metrics.NewCollectors(metricsRegistry)
+	vxRaftNode.Fsm.Metrics = metricsCollectors
+
+	metricsCtx, cancelMetrics := context.WithCancel(context.Background())
+	defer cancelMetrics()
+	go vxRaftNode.RunMetricsLoop(metricsCtx, metricsCollectors, 2*time.Second)
+
+	if *metricsAddr != "" {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}))
+		go func() {
+			log.Printf("[%s] Metrics listening on %s/metrics", *nodeID, *metricsAddr)
+			if err := http.ListenAndServe(*metricsAddr, metricsMux); err != nil {
+				log.Fatalf("[%s] Failed to serve metrics: %v", *nodeID, err)
+			}
+		}()
+	}
+
+	// peerDialCreds is used for every raw node-to-node dial in this file
+	// (ReadForwarder, probeNodeStatus), so they pick up the same -tls-*
+	// flags as the cluster gRPC server itself instead of always dialing
+	// plaintext.
+	peerDialCreds, err := server.LoadPeerDialCredentials(*tlsCert, *tlsKey, *tlsCA)
+	if err != nil {
+		log.Fatalf("Failed to load peer dial credentials: %v", err)
+	}
+
+	// Forward follower ReadIndex queries to the current leader's cluster
+	// gRPC address, so Search's Linearizable path can be served off a
+	// follower without a leader round-trip for the search itself.
+	vxRaftNode.ReadForwarder = func(ctx context.Context, leaderRaftAddr string) (uint64, error) {
+		leaderClusterAddr, err := server.ConvertRaftToClusterAddr(leaderRaftAddr)
+		if err != nil {
+			return 0, fmt.Errorf("convert leader address: %w", err)
+		}
+
+		conn, err := grpc.DialContext(ctx, leaderClusterAddr, grpc.WithTransportCredentials(peerDialCreds), grpc.WithBlock())
+		if err != nil {
+			return 0, fmt.Errorf("dial leader %s: %w", leaderClusterAddr, err)
+		}
+		defer conn.Close()
+
+		resp, err := pb.NewClusterServiceClient(conn).ReadIndex(ctx, &pb.ReadIndexRequest{})
+		if err != nil {
+			return 0, fmt.Errorf("forward read index to %s: %w", leaderClusterAddr, err)
+		}
+		return resp.Index, nil
+	}
+
+	// ChunkingApplier splits any oversized Insert/InsertBatch command across
+	// several Raft log entries instead of proposing it as one, per node's
+	// -max-command-bytes (0 = consensus.defaultMaxCommandBytes).
+	chunkingApplier := consensus.NewChunkingApplier(vxRaftNode, int(*maxCommandBytes))
+
+	// probeNodeStatus lets GetClusterInfo ask a learner node directly for
+	// its own NodeStatus, to tell a still-catching-up learner apart from
+	// one that's ready to be promoted.
+	probeNodeStatus := func(ctx context.Context, nodeRaftAddr string) (*pb.NodeStatusResponse, error) {
+		nodeAddr, err := server.ConvertRaftToClusterAddr(nodeRaftAddr)
+		if err != nil {
+			return nil, fmt.Errorf("node cluster address: %w", err)
+		}
+		conn, err := grpc.DialContext(ctx, nodeAddr, grpc.WithTransportCredentials(peerDialCreds), grpc.WithBlock())
+		if err != nil {
+			return nil, fmt.Errorf("dial node %s: %w", nodeAddr, err)
+		}
+		defer conn.Close()
+
+		return pb.NewClusterServiceClient(conn).NodeStatus(ctx, &pb.NodeStatusRequest{})
+	}
+
+	// healthCheckNode dials a prospective member's own address before
+	// JoinCluster adds it to the Raft configuration, the same round-trip
+	// probeNodeStatus makes to a learner, just cheaper: GetClusterInfo
+	// doesn't require the peer to already be part of this cluster's Raft
+	// configuration the way NodeStatus effectively implies.
+	healthCheckNode := func(ctx context.Context, nodeRaftAddr string) error {
+		nodeAddr, err := server.ConvertRaftToClusterAddr(nodeRaftAddr)
+		if err != nil {
+			return fmt.Errorf("node cluster address: %w", err)
+		}
+		conn, err := grpc.DialContext(ctx, nodeAddr, grpc.WithTransportCredentials(peerDialCreds), grpc.WithBlock())
+		if err != nil {
+			return fmt.Errorf("dial %s: %w", nodeAddr, err)
+		}
+		defer conn.Close()
+
+		_, err = pb.NewClusterServiceClient(conn).GetClusterInfo(ctx, &pb.GetClusterInfoRequest{})
+		if err != nil {
+			return fmt.Errorf("probe %s: %w", nodeAddr, err)
+		}
+		return nil
+	}
+
+	// onLeaveClusterSelf is the automatic final step of Drain: by the time
+	// it's called, this node has already transferred away leadership if it
+	// held it, so vxRaftNode.Leader() points at whoever is leader now, and
+	// this just asks them to RemoveServer this node the way an operator's
+	// 'leave'/'demote' call would.
+	onLeaveClusterSelf := func(ctx context.Context) error {
+		leaderRaftAddr := string(vxRaftNode.Leader())
+		if leaderRaftAddr == "" {
+			return fmt.Errorf("no leader available to remove this node")
+		}
+		leaderClusterAddr, err := server.ConvertRaftToClusterAddr(leaderRaftAddr)
+		if err != nil {
+			return fmt.Errorf("convert leader address: %w", err)
+		}
+
+		conn, err := grpc.DialContext(ctx, leaderClusterAddr, grpc.WithTransportCredentials(peerDialCreds), grpc.WithBlock())
+		if err != nil {
+			return fmt.Errorf("dial leader %s: %w", leaderClusterAddr, err)
+		}
+		defer conn.Close()
+
+		_, err = pb.NewClusterServiceClient(conn).LeaveCluster(ctx, &pb.LeaveClusterRequest{NodeId: *nodeID})
+		return err
+	}
+
+	// watchProgressInterval is how often onWatch sends a Progress-only
+	// WatchEvent to an idle subscriber so it can checkpoint StartRevision
+	// without waiting on an actual data event.
+	const watchProgressInterval = 10 * time.Second
+
+	// onWatch streams WatchHub events matching req to send, resuming from
+	// req.StartRevision and optionally narrowing to a region of the
+	// embedding space (req.CenterVector/req.Radius), until ctx is done.
+	onWatch := func(ctx context.Context, req *pb.WatchRequest, send func(*pb.WatchEvent) error) error {
+		watchHub := vxRaftNode.Fsm.WatchHub
+		afterIndex := req.StartRevision
+
+		ticker := time.NewTicker(watchProgressInterval)
+		defer ticker.Stop()
+
+		for {
+			events, err := watchHub.Since(afterIndex)
+			if errors.Is(err, consensus.ErrWatchCompacted) {
+				return status.Errorf(codes.OutOfRange, "watch %s: %v", req.CollectionName, err)
+			}
+			if err != nil {
+				return fmt.Errorf("watch %s: %w", req.CollectionName, err)
+			}
+
+			for _, ev := range events {
+				afterIndex = ev.Index
+				if req.CollectionName != "" && ev.CollectionName != req.CollectionName {
+					continue
+				}
+				if len(req.CenterVector) > 0 && !withinRadius(req.CenterVector, ev.Vector, req.Radius) {
+					continue
+				}
+				if err := send(&pb.WatchEvent{
+					Index:          ev.Index,
+					EventType:      ev.Type.String(),
+					CollectionName: ev.CollectionName,
+					PointId:        ev.PointID,
+					Vector:         ev.Vector,
+				}); err != nil {
+					return err
+				}
+			}
+
+			// WaitForMore blocks until a newer event is published or ctx is
+			// done; run it in a goroutine so a periodic Progress message can
+			// still be sent to an otherwise-idle subscriber in the meantime.
+			woke := make(chan struct{})
+			go func() {
+				watchHub.WaitForMore(ctx, afterIndex)
+				close(woke)
+			}()
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-woke:
+			case <-ticker.C:
+				if err := send(&pb.WatchEvent{Index: vxRaftNode.LastApplied(), Progress: true}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// onCreateBackup/onRestoreBackup trigger an out-of-band FSM snapshot
+	// export/import to/from a local path, independent of Raft's own
+	// snapshot cycle. s3:// destinations are recognized but not yet wired
+	// to an actual uploader, so they fail explicitly rather than silently
+	// writing a local file under that name.
+	onCreateBackup := func(ctx context.Context, dest string) error {
+		if strings.HasPrefix(dest, "s3://") {
+			return fmt.Errorf("backup destination %q: s3:// is not yet supported, use a local path", dest)
+		}
+
+		fsmSnapshot, err := vxRaftNode.Fsm.Snapshot()
+		if err != nil {
+			return fmt.Errorf("snapshot FSM: %w", err)
+		}
+		defer fsmSnapshot.Release()
+
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return fmt.Errorf("create backup directory: %w", err)
+		}
+		f, err := os.Create(dest)
+		if err != nil {
+			return fmt.Errorf("create backup file %s: %w", dest, err)
+		}
+
+		sink := &fileSnapshotSink{File: f}
+		if err := fsmSnapshot.Persist(sink); err != nil {
+			sink.Cancel()
+			return fmt.Errorf("persist backup to %s: %w", dest, err)
+		}
+		return nil
+	}
+
+	onRestoreBackup := func(ctx context.Context, src string) error {
+		if strings.HasPrefix(src, "s3://") {
+			return fmt.Errorf("restore source %q: s3:// is not yet supported, use a local path", src)
+		}
+		f, err := os.Open(src)
+		if err != nil {
+			return fmt.Errorf("open backup file %s: %w", src, err)
+		}
+		return vxRaftNode.Fsm.Restore(f)
+	}
+
 	// Configure cluster server
 	serverCfg := server.ClusterServerConfig{
-		RaftNode: vxRaftNode,
+		RaftNode:                    vxRaftNode,
+		NodeID:                      *nodeID,
+		NodeAddr:                    raftAddr,
+		ProbeNodeStatus:             probeNodeStatus,
+		OnWaitForIndex:              vxRaftNode.Fsm.ReadIndexWaiter.Wait,
+		OnHealthCheckNode:           healthCheckNode,
+		OnWatch:                     onWatch,
+		OnCreateBackup:              onCreateBackup,
+		OnRestoreBackup:             onRestoreBackup,
+		OnLeaveClusterSelf:          onLeaveClusterSelf,
+		MinSupportedProtocolVersion: consensus.MinSupportedProtocolVersion,
+		MaxSupportedProtocolVersion: consensus.MaxSupportedProtocolVersion,
+
+		// OnRegisterCapabilities replicates a joining node's advertised
+		// capabilities via a normal Raft command, the same path Insert/
+		// Delete/etc. use, so every FSM (not just the leader's in-memory
+		// view) ends up agreeing on the cluster-wide minimum.
+		OnRegisterCapabilities: func(ctx context.Context, nodeID string, protocolVersion int32, features map[string]bool) error {
+			payload, err := json.Marshal(consensus.RegisterCapabilitiesPayload{
+				NodeID: nodeID,
+				Capabilities: consensus.ClusterCapabilities{
+					ProtocolVersion: int(protocolVersion),
+					Features:        features,
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("marshal capabilities payload: %w", err)
+			}
+			future, err := chunkingApplier.Apply(consensus.Command{Type: consensus.CmdRegisterCapabilities, Payload: payload}, 5*time.Second)
+			if err != nil {
+				return err
+			}
+			return future.Error()
+		},
+
+		OnGetMinClusterCapability: func(ctx context.Context) (*pb.ClusterCapabilities, error) {
+			min := consensus.MinClusterCapability(vxRaftNode.Fsm)
+			return &pb.ClusterCapabilities{
+				ProtocolVersion: int32(min.ProtocolVersion),
+				Features:        min.Features,
+			}, nil
+		},
 
 		OnCreateCollection: func(ctx context.Context, req *pb.CreateCollectionRequest) error {
 			log.Printf("[%s] Creating collection: %s", *nodeID, req.CollectionName)
@@ -119,15 +444,17 @@ func main() {
 				return fmt.Errorf("failed to marshal insert request: %w", err)
 			}
 
-			comm, err := json.Marshal(consensus.Command{
+			// ChunkingApplier transparently splits this command across
+			// several Raft entries if the vector/payload makes it too big
+			// for one, instead of stalling replication on an oversized
+			// AppendEntries.
+			future, err := chunkingApplier.Apply(consensus.Command{
 				Type:    consensus.CmdInsert,
 				Payload: payload,
-			})
+			}, 5*time.Second)
 			if err != nil {
-				return fmt.Errorf("failed to marshal command: %w", err)
+				return fmt.Errorf("failed to apply insert command: %w", err)
 			}
-
-			future := vxRaftNode.Apply(comm, 5*time.Second)
 			if err := future.Error(); err != nil {
 				log.Printf("[%s] ERROR: Raft Apply failed for Insert: %v", *nodeID, err)
 				return fmt.Errorf("raft apply failed: %w", err)
@@ -137,11 +464,183 @@ func main() {
 			return nil
 		},
 
+		OnBatchInsert: func(ctx context.Context, req *pb.InsertBatchRequest) ([]*pb.InsertResult, error) {
+			log.Printf("[%s] Batch inserting %d points", *nodeID, len(req.Points))
+
+			points := make([]types.InsertPoint, 0, len(req.Points))
+			for _, p := range req.Points {
+				insertReq, err := types.NewInsertPointBuilder().
+					CollectionName(p.CollectionName).
+					Id(p.Id).
+					Vector(p.Vector).
+					PayloadInsertQuery(p.PayloadInsertQuery).
+					Build()
+				if err != nil {
+					return nil, fmt.Errorf("failed to build insert point %d: %w", p.Id, err)
+				}
+				points = append(points, *insertReq)
+			}
+
+			payload, err := json.Marshal(points)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal insert batch: %w", err)
+			}
+
+			future, err := chunkingApplier.Apply(consensus.Command{
+				Type:    consensus.CmdInsertBatch,
+				Payload: payload,
+			}, 5*time.Second)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply insert batch command: %w", err)
+			}
+			if err := future.Error(); err != nil {
+				log.Printf("[%s] ERROR: Raft Apply failed for InsertBatch: %v", *nodeID, err)
+				return nil, fmt.Errorf("raft apply failed: %w", err)
+			}
+
+			itemResults, ok := future.Response().(*consensus.ApplyResult).Data.([]consensus.InsertBatchItemResult)
+			if !ok {
+				return nil, fmt.Errorf("unexpected InsertBatch apply response")
+			}
+
+			results := make([]*pb.InsertResult, len(itemResults))
+			for i, r := range itemResults {
+				if r.Error != nil {
+					results[i] = &pb.InsertResult{Success: false, Message: r.Error.Error()}
+					continue
+				}
+				results[i] = &pb.InsertResult{Success: true, Message: "inserted successfully"}
+			}
+
+			log.Printf("[%s] Successfully batch inserted %d points", *nodeID, len(points))
+			return results, nil
+		},
+
+		OnBulkInsert: func(ctx context.Context, reqs []*pb.InsertRequest) ([]*pb.InsertResult, error) {
+			log.Printf("[%s] Bulk inserting %d points", *nodeID, len(reqs))
+
+			points := make([]types.InsertPoint, 0, len(reqs))
+			for _, req := range reqs {
+				insertReq, err := types.NewInsertPointBuilder().
+					CollectionName(req.CollectionName).
+					Id(req.Id).
+					Vector(req.Vector).
+					PayloadInsertQuery(req.PayloadInsertQuery).
+					Build()
+				if err != nil {
+					return nil, fmt.Errorf("failed to build insert point %d: %w", req.Id, err)
+				}
+				points = append(points, *insertReq)
+			}
+
+			payload, err := json.Marshal(points)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal bulk insert batch: %w", err)
+			}
+
+			future, err := chunkingApplier.Apply(consensus.Command{
+				Type:    consensus.CmdBulkInsert,
+				Payload: payload,
+			}, 5*time.Second)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply bulk insert command: %w", err)
+			}
+			if err := future.Error(); err != nil {
+				log.Printf("[%s] ERROR: Raft Apply failed for BulkInsert: %v", *nodeID, err)
+				return nil, fmt.Errorf("raft apply failed: %w", err)
+			}
+
+			itemResults, ok := future.Response().(*consensus.ApplyResult).Data.([]consensus.InsertBatchItemResult)
+			if !ok {
+				return nil, fmt.Errorf("unexpected BulkInsert apply response")
+			}
+
+			results := make([]*pb.InsertResult, len(itemResults))
+			for i, r := range itemResults {
+				if r.Error != nil {
+					results[i] = &pb.InsertResult{Success: false, Message: r.Error.Error()}
+					continue
+				}
+				results[i] = &pb.InsertResult{Success: true, Message: "inserted successfully"}
+			}
+
+			log.Printf("[%s] Successfully bulk inserted %d points", *nodeID, len(points))
+			return results, nil
+		},
+
 		OnDelete: func(ctx context.Context, req *pb.DeleteRequest) error {
 			log.Printf("[%s] Deleting from collection: %s", *nodeID, req.CollectionName)
+
+			payload, err := json.Marshal(consensus.DeletePayload{
+				CollectionName: req.CollectionName,
+				ID:             req.Id,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to marshal delete payload: %w", err)
+			}
+
+			future, err := chunkingApplier.Apply(consensus.Command{
+				Type:    consensus.CmdDelete,
+				Payload: payload,
+			}, 5*time.Second)
+			if err != nil {
+				return fmt.Errorf("failed to apply delete command: %w", err)
+			}
+			if err := future.Error(); err != nil {
+				log.Printf("[%s] ERROR: Raft Apply failed for Delete: %v", *nodeID, err)
+				return fmt.Errorf("raft apply failed: %w", err)
+			}
+
+			log.Printf("[%s] Successfully deleted from collection: %s", *nodeID, req.CollectionName)
 			return nil
 		},
 
+		OnDeleteBatch: func(ctx context.Context, req *pb.DeleteBatchRequest) ([]*pb.DeleteResult, error) {
+			log.Printf("[%s] Batch deleting %d points", *nodeID, len(req.Points))
+
+			payloads := make([]consensus.DeletePayload, len(req.Points))
+			for i, p := range req.Points {
+				payloads[i] = consensus.DeletePayload{
+					CollectionName: p.CollectionName,
+					ID:             p.Id,
+				}
+			}
+
+			payload, err := json.Marshal(payloads)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal delete batch: %w", err)
+			}
+
+			future, err := chunkingApplier.Apply(consensus.Command{
+				Type:    consensus.CmdDeleteBatch,
+				Payload: payload,
+			}, 5*time.Second)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply delete batch command: %w", err)
+			}
+			if err := future.Error(); err != nil {
+				log.Printf("[%s] ERROR: Raft Apply failed for DeleteBatch: %v", *nodeID, err)
+				return nil, fmt.Errorf("raft apply failed: %w", err)
+			}
+
+			itemResults, ok := future.Response().(*consensus.ApplyResult).Data.([]consensus.DeleteBatchItemResult)
+			if !ok {
+				return nil, fmt.Errorf("unexpected DeleteBatch apply response")
+			}
+
+			results := make([]*pb.DeleteResult, len(itemResults))
+			for i, r := range itemResults {
+				if r.Error != nil {
+					results[i] = &pb.DeleteResult{Success: false, Message: r.Error.Error()}
+					continue
+				}
+				results[i] = &pb.DeleteResult{Success: true, Message: "deleted successfully"}
+			}
+
+			log.Printf("[%s] Successfully batch deleted %d points", *nodeID, len(payloads))
+			return results, nil
+		},
+
 		OnSearch: func(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
 			log.Printf("[%s] Searching in collection: %s", *nodeID, req.CollectionName)
 
@@ -192,15 +691,61 @@ func main() {
 	// Create cluster server
 	clusterServer := server.NewClusterServer(serverCfg)
 
+	// Wire the FSM's Apply path into the server's LagTracker so
+	// BoundedStaleness reads reflect the time since this node last applied
+	// an entry instead of always reporting zero.
+	vxRaftNode.Fsm.OnApplied = clusterServer.RecordApplied
+
 	// Create interceptors
 	leaderInterceptor := server.NewLeaderRedirectInterceptor(vxRaftNode)
 	loggingInterceptor := server.NewLoggingInterceptor()
 
-	// Create gRPC server with interceptors
+	var authPolicy map[string]server.Role
+	if *authPolicyFile != "" {
+		authPolicy, err = server.LoadAuthPolicyFile(*authPolicyFile)
+		if err != nil {
+			log.Fatalf("Failed to load -auth-policy: %v", err)
+		}
+	}
+	var authTokens map[string]server.Role
+	if *authTokensFile != "" {
+		authTokens, err = server.LoadAuthTokensFile(*authTokensFile)
+		if err != nil {
+			log.Fatalf("Failed to load -auth-tokens: %v", err)
+		}
+	}
+	authInterceptor := server.NewAuthInterceptor(*tlsCA != "", authTokens, authPolicy)
+
+	// Server transport credentials: plaintext unless -tls-cert/-tls-key
+	// are set, mTLS (requiring and verifying a client certificate) if
+	// -tls-ca is also set, which is what lets authInterceptor authenticate
+	// callers by their peer certificate's CommonName.
+	var serverCreds credentials.TransportCredentials
+	if *tlsCert != "" {
+		serverCreds, err = server.LoadServerTLSCredentials(*tlsCert, *tlsKey, *tlsCA)
+		if err != nil {
+			log.Fatalf("Failed to load server TLS credentials: %v", err)
+		}
+	} else {
+		serverCreds = insecure.NewCredentials()
+	}
+
+	// Create gRPC server with interceptors. metricsCollectors.UnaryInterceptor
+	// wraps every handler, including OnCreateCollection/OnInsert/OnSearch
+	// above, with the RPCLatency/RPCTotal metrics behind the Grafana
+	// dashboard's QPS and latency panels.
 	grpcServer := grpc.NewServer(
+		grpc.Creds(serverCreds),
 		grpc.ChainUnaryInterceptor(
+			authInterceptor.Unary(),
 			leaderInterceptor.Unary(),
 			loggingInterceptor.Unary(),
+			metricsCollectors.UnaryInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			authInterceptor.Stream(),
+			leaderInterceptor.Stream(),
+			loggingInterceptor.Stream(),
 		),
 	)
 
@@ -221,6 +766,17 @@ func main() {
 		}
 	}()
 
+	if *join != "" {
+		seeds := strings.Split(*join, ",")
+		go func() {
+			joinCtx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			if err := consensus.JoinCluster(joinCtx, seeds, *nodeID, raftAddr); err != nil {
+				log.Fatalf("[%s] Failed to join cluster via seeds %v: %v", *nodeID, seeds, err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -231,3 +787,54 @@ func main() {
 	vxRaftNode.Shutdown()
 	log.Printf("[%s] Shutdown complete", *nodeID)
 }
+
+// fileSnapshotSink adapts a local *os.File to raft.SnapshotSink, so
+// onCreateBackup can hand consensus.VxFSM.Snapshot's raft.FSMSnapshot
+// straight to Persist for a one-off backup, without standing up a full
+// raft.SnapshotStore the way the node's own Raft instance does.
+type fileSnapshotSink struct {
+	*os.File
+}
+
+func (s *fileSnapshotSink) ID() string { return s.Name() }
+
+func (s *fileSnapshotSink) Cancel() error {
+	s.Close()
+	return os.Remove(s.Name())
+}
+
+// withinRadius reports whether vec lies within radius of center under
+// Euclidean distance, the embedding-space filter onWatch applies to a
+// WatchRequest's optional CenterVector/Radius. Mismatched dimensions (e.g.
+// a non-insert event with no vector) never match.
+func withinRadius(center, vec []float32, radius float32) bool {
+	if len(center) != len(vec) || len(vec) == 0 {
+		return false
+	}
+	var sumSq float64
+	for i := range center {
+		d := float64(center[i] - vec[i])
+		sumSq += d * d
+	}
+	return math.Sqrt(sumSq) <= float64(radius)
+}
+
+// parsePeers parses -peers' "id=raftAddr,id=raftAddr,..." syntax into the
+// initial voter set for a one-shot multi-node bootstrap. An empty string
+// returns a nil, empty slice.
+func parsePeers(peers string) ([]consensus.NodeInfo, error) {
+	if peers == "" {
+		return nil, nil
+	}
+
+	entries := strings.Split(peers, ",")
+	infos := make([]consensus.NodeInfo, 0, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid -peers entry %q, want id=raftAddr", entry)
+		}
+		infos = append(infos, consensus.NodeInfo{ID: parts[0], Addr: parts[1]})
+	}
+	return infos, nil
+}
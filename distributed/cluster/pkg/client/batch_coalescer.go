@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/uttom-akash/vector-xlite/distributed/cluster/pkg/pb"
+)
+
+// insertCoalesceRequest carries one caller's pending Insert call into the
+// coalescer, along with the channel its result is delivered on.
+type insertCoalesceRequest struct {
+	req    *pb.InsertRequest
+	result chan insertCoalesceResult
+}
+
+type insertCoalesceResult struct {
+	resp *pb.InsertResponse
+	err  error
+}
+
+// insertCoalescer merges concurrent Insert calls that arrive within a
+// short window into a single InsertBatch proposal, the way etcd batches
+// concurrent writes into one raft entry instead of paying a full
+// consensus round-trip per call.
+type insertCoalescer struct {
+	client       *ClusterClient
+	window       time.Duration
+	maxBatchSize int
+
+	mu      sync.Mutex
+	pending []*insertCoalesceRequest
+	timer   *time.Timer
+}
+
+// newInsertCoalescer creates a coalescer that flushes every window, or
+// immediately once maxBatchSize requests are pending, whichever comes
+// first. maxBatchSize <= 0 defaults to 100.
+func newInsertCoalescer(c *ClusterClient, window time.Duration, maxBatchSize int) *insertCoalescer {
+	if maxBatchSize <= 0 {
+		maxBatchSize = 100
+	}
+	return &insertCoalescer{
+		client:       c,
+		window:       window,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+// Insert enqueues req and blocks until the batch it ends up in has been
+// proposed and this point's individual result is known, or ctx is done.
+func (b *insertCoalescer) Insert(ctx context.Context, req *pb.InsertRequest) (*pb.InsertResponse, error) {
+	pending := &insertCoalesceRequest{req: req, result: make(chan insertCoalesceResult, 1)}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, pending)
+	var batch []*insertCoalesceRequest
+	if len(b.pending) >= b.maxBatchSize {
+		batch = b.pending
+		b.pending = nil
+		if b.timer != nil {
+			b.timer.Stop()
+			b.timer = nil
+		}
+	} else if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.flushPending)
+	}
+	b.mu.Unlock()
+
+	if batch != nil {
+		go b.flush(batch)
+	}
+
+	select {
+	case res := <-pending.result:
+		return res.resp, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// flushPending fires when the batch window elapses with no MaxBatchSize
+// flush having happened yet.
+func (b *insertCoalescer) flushPending() {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.timer = nil
+	b.mu.Unlock()
+
+	if len(batch) > 0 {
+		b.flush(batch)
+	}
+}
+
+// flush sends batch as a single InsertBatch proposal and fans the
+// per-index results back out to each waiting caller.
+func (b *insertCoalescer) flush(batch []*insertCoalesceRequest) {
+	points := make([]*pb.InsertRequest, len(batch))
+	for i, p := range batch {
+		points[i] = p.req
+	}
+
+	resp, err := b.client.InsertBatch(context.Background(), points)
+	if err != nil {
+		for _, p := range batch {
+			p.result <- insertCoalesceResult{err: err}
+		}
+		return
+	}
+
+	for i, p := range batch {
+		if i >= len(resp.Results) {
+			p.result <- insertCoalesceResult{err: fmt.Errorf("insert batch item %d: no result returned", i)}
+			continue
+		}
+
+		r := resp.Results[i]
+		var itemErr error
+		if !r.Success {
+			itemErr = fmt.Errorf("insert batch item %d failed: %s", i, r.Message)
+		}
+		p.result <- insertCoalesceResult{
+			resp: &pb.InsertResponse{Success: r.Success, Message: r.Message},
+			err:  itemErr,
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	pb "github.com/uttom-akash/vector-xlite/distributed/cluster/pkg/pb"
+)
+
+// BatchInsertOptions configures BatchInsert's bounded-concurrency dispatch.
+type BatchInsertOptions struct {
+	// MaxConcurrent bounds the number of in-flight Insert RPCs (default: 20).
+	MaxConcurrent int
+}
+
+// BatchInsertFailure records the original index of a request that failed.
+type BatchInsertFailure struct {
+	Index int
+	Err   error
+}
+
+// BatchInsertResult reports the outcome of a BatchInsert call.
+type BatchInsertResult struct {
+	Successes []int
+	Failures  []BatchInsertFailure
+	FirstErr  error
+}
+
+// BatchInsert inserts many points concurrently, pinning every sub-request to
+// the connection currently believed to be the leader so the batch doesn't
+// pay a per-call leader lookup. Individual requests are still routed through
+// the usual redirect interceptor in case leadership changed mid-batch.
+func (c *ClusterClient) BatchInsert(ctx context.Context, reqs []*pb.InsertRequest, opts BatchInsertOptions) (*BatchInsertResult, error) {
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = 20
+	}
+
+	gate := make(chan struct{}, opts.MaxConcurrent)
+	result := &BatchInsertResult{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i, req := range reqs {
+		wg.Add(1)
+		gate <- struct{}{}
+		go func(index int, r *pb.InsertRequest) {
+			defer wg.Done()
+			defer func() { <-gate }()
+
+			_, err := c.Insert(ctx, r)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failures = append(result.Failures, BatchInsertFailure{Index: index, Err: err})
+				if result.FirstErr == nil {
+					result.FirstErr = err
+				}
+				return
+			}
+			result.Successes = append(result.Successes, index)
+		}(i, req)
+	}
+
+	wg.Wait()
+	return result, nil
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/uttom-akash/vector-xlite/distributed/cluster/pkg/pb"
+)
+
+// BulkInsert streams reqs to the leader over a single BulkInsert RPC,
+// coalescing them server-side into batched Raft proposals instead of one
+// round trip per point (see ClusterServer.BulkInsert). Unlike BatchInsert,
+// which fans concurrent unary Insert calls out over the network, the
+// points here never leave this one stream, so the caller controls
+// parallelism (if any) by calling BulkInsert concurrently over disjoint
+// slices of reqs — see vxctl's "bulk-insert" subcommand's -parallel flag.
+func (c *ClusterClient) BulkInsert(ctx context.Context, reqs []*pb.InsertRequest) (*pb.BulkInsertResponse, error) {
+	cl, err := c.streamClient(BalancerLeaderOnly)
+	if err != nil {
+		return nil, fmt.Errorf("select endpoint: %w", err)
+	}
+
+	stream, err := cl.BulkInsert(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open bulk insert stream: %w", err)
+	}
+
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			return nil, fmt.Errorf("send insert request: %w", err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("close bulk insert stream: %w", err)
+	}
+	return resp, nil
+}
@@ -3,19 +3,36 @@ package client
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	pb "github.com/uttom-akash/vector-xlite/distributed/cluster/pkg/pb"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// ClusterClient wraps the gRPC client with convenience methods
+// defaultRefreshInterval is how often a ClusterClient re-resolves cluster
+// membership in the background when RefreshInterval isn't set.
+const defaultRefreshInterval = 30 * time.Second
+
+// ClusterClient wraps the gRPC client with convenience methods. It can
+// track multiple endpoints (see ClientConfig.Endpoints): reads are load
+// balanced across known nodes, writes target the cached leader directly,
+// and a call that hits an unreachable node transparently retries on
+// another one before returning an error to the caller.
 type ClusterClient struct {
-	conn                *grpc.ClientConn
-	client              pb.ClusterServiceClient
+	resolver            *endpointResolver
+	balancer            BalancerMode
+	maxFailoverAttempts int
 	addr                string
 	redirectInterceptor *RedirectInterceptor
+	refreshInterval     time.Duration
+	stopCh              chan struct{}
+
+	// coalescer, if configured via ClientConfig.BatchWindow, merges
+	// concurrent Insert calls into a single InsertBatch proposal.
+	coalescer *insertCoalescer
 }
 
 // ClientConfig holds configuration for creating a ClusterClient
@@ -23,6 +40,32 @@ type ClientConfig struct {
 	// Address of any node in the cluster (seed node)
 	Addr string
 
+	// Endpoints, if set, seeds the client with the full known node list
+	// instead of (or in addition to) Addr, the way etcd's clientv3 takes
+	// a list of cluster members up front rather than discovering them
+	// lazily from a single seed.
+	Endpoints []string
+
+	// Balancer selects how read calls pick among known endpoints
+	// (default: BalancerRoundRobin). Writes always use BalancerLeaderOnly
+	// regardless of this setting.
+	Balancer BalancerMode
+
+	// RefreshInterval controls how often the client re-resolves cluster
+	// membership in the background via GetClusterInfo (default: 30s).
+	RefreshInterval time.Duration
+
+	// BatchWindow, if nonzero, coalesces concurrent Insert calls that
+	// arrive within this window into a single InsertBatch proposal,
+	// similar to how etcd batches concurrent writes into one raft
+	// entry. Zero (the default) sends every Insert as its own proposal.
+	BatchWindow time.Duration
+
+	// MaxBatchSize bounds how many points the coalescer merges into one
+	// InsertBatch proposal before flushing early, even if BatchWindow
+	// hasn't elapsed yet (default: 100). Only used when BatchWindow > 0.
+	MaxBatchSize int
+
 	// Maximum number of redirects to follow (default: 3)
 	MaxRedirects int
 
@@ -31,42 +74,116 @@ type ClientConfig struct {
 
 	// Connection timeout (default: 5s)
 	ConnectTimeout time.Duration
+
+	// TLSCertFile/TLSKeyFile/TLSCAFile configure the client's transport
+	// credentials: plaintext if all three are empty, server-TLS if only
+	// TLSCAFile is set, or mTLS (presenting this client's own certificate)
+	// if TLSCertFile/TLSKeyFile are also set.
+	TLSCertFile string
+	TLSKeyFile  string
+	TLSCAFile   string
+
+	// AuthToken, if set, is attached as a bearer credential on every
+	// call, for a cluster with server.AuthInterceptor's token auth
+	// enabled.
+	AuthToken string
+
+	// RetryPolicy controls how RedirectInterceptor retries a call that
+	// fails with codes.Unavailable (no leader available mid-election)
+	// instead of failing it immediately. Nil uses DefaultRetryPolicy().
+	RetryPolicy *RetryPolicy
 }
 
-// NewClusterClient creates a new cluster client with automatic leader redirection
+// NewClusterClient creates a new cluster client with automatic leader
+// redirection, health-based failover across every known endpoint, and a
+// background membership refresh.
 func NewClusterClient(cfg ClientConfig) (*ClusterClient, error) {
 	if cfg.ConnectTimeout == 0 {
 		cfg.ConnectTimeout = 5 * time.Second
 	}
+	if cfg.Balancer == "" {
+		cfg.Balancer = BalancerRoundRobin
+	}
+	if cfg.RefreshInterval == 0 {
+		cfg.RefreshInterval = defaultRefreshInterval
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
-	defer cancel()
+	seeds := append([]string(nil), cfg.Endpoints...)
+	if cfg.Addr != "" {
+		seeds = append(seeds, cfg.Addr)
+	}
+	if len(seeds) == 0 {
+		return nil, fmt.Errorf("client: no seed address or endpoints configured")
+	}
+
+	transportCreds, err := buildTransportCredentials(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("build transport credentials: %w", err)
+	}
 
-	// Create interceptors
-	redirectInterceptor := NewRedirectInterceptor(cfg.MaxRedirects)
+	authInterceptor := NewAuthInterceptor(cfg.AuthToken)
 	loggingInterceptor := NewLoggingInterceptor(cfg.VerboseLogging)
 
-	// Dial with interceptors
-	conn, err := grpc.DialContext(
-		ctx,
-		cfg.Addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
+	// redirectInterceptor dials newly-discovered leaders with the same
+	// transport credentials and bearer token as every other call, so a
+	// TLS/auth-enabled cluster doesn't silently fall back to plaintext
+	// on a redirected call. seeds lets it re-resolve the leader by
+	// querying GetClusterInfo directly while retrying a call that fails
+	// with Unavailable during an election.
+	redirectInterceptor := NewRedirectInterceptor(
+		cfg.MaxRedirects,
+		seeds,
+		cfg.RetryPolicy,
+		grpc.WithTransportCredentials(transportCreds),
+		grpc.WithChainUnaryInterceptor(authInterceptor.Unary()),
+	)
+	redirectInterceptor.onRetry = loggingInterceptor.LogRetry
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
 		grpc.WithChainUnaryInterceptor(
 			redirectInterceptor.Unary(),
 			loggingInterceptor.Unary(),
+			authInterceptor.Unary(),
 		),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to %s: %w", cfg.Addr, err)
+		grpc.WithChainStreamInterceptor(authInterceptor.Stream()),
 	}
 
-	return &ClusterClient{
-		conn:                conn,
-		client:              pb.NewClusterServiceClient(conn),
-		addr:                cfg.Addr,
+	resolver := newEndpointResolver(seeds, dialOpts)
+
+	// Confirm the seed is reachable up front, the way the single-endpoint
+	// client used grpc.WithBlock to fail fast on a bad address. Other
+	// endpoints are dialed lazily, on first use, by the resolver.
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+	defer cancel()
+	if _, err := resolver.dialBlocking(ctx, seeds[0]); err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", seeds[0], err)
+	}
+
+	redirectInterceptor.onLeaderHint = resolver.setLeader
+
+	maxFailoverAttempts := len(seeds)
+	if maxFailoverAttempts < 2 {
+		maxFailoverAttempts = 2
+	}
+
+	c := &ClusterClient{
+		resolver:            resolver,
+		balancer:            cfg.Balancer,
+		maxFailoverAttempts: maxFailoverAttempts,
+		addr:                seeds[0],
 		redirectInterceptor: redirectInterceptor,
-	}, nil
+		refreshInterval:     cfg.RefreshInterval,
+		stopCh:              make(chan struct{}),
+	}
+
+	if cfg.BatchWindow > 0 {
+		c.coalescer = newInsertCoalescer(c, cfg.BatchWindow, cfg.MaxBatchSize)
+	}
+
+	go c.refreshLoop()
+
+	return c, nil
 }
 
 // NewClusterClientSimple creates a client with default settings
@@ -79,12 +196,14 @@ func NewClusterClientSimple(addr string) (*ClusterClient, error) {
 	})
 }
 
-// Close closes the gRPC connection and cleanup resources
+// Close closes every connection this client has dialed and stops the
+// background refresh loop.
 func (c *ClusterClient) Close() error {
+	close(c.stopCh)
 	if c.redirectInterceptor != nil {
 		c.redirectInterceptor.Close()
 	}
-	return c.conn.Close()
+	return c.resolver.close()
 }
 
 // GetAddr returns the initial address this client connected to
@@ -92,37 +211,183 @@ func (c *ClusterClient) GetAddr() string {
 	return c.addr
 }
 
+// Endpoints returns the client's currently known endpoints, as of the
+// last Sync or background refresh.
+func (c *ClusterClient) Endpoints() []string {
+	return c.resolver.snapshot()
+}
+
+// Sync refreshes the client's known endpoints and leader cache by calling
+// GetClusterInfo against any reachable node, the same way etcd's
+// clientv3 periodically re-resolves cluster membership.
+func (c *ClusterClient) Sync(ctx context.Context) error {
+	info, err := c.GetClusterInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("sync cluster membership: %w", err)
+	}
+
+	for _, node := range info.Nodes {
+		c.resolver.addEndpoint(node.Addr)
+	}
+	if info.LeaderAddr != "" {
+		c.resolver.setLeader(info.LeaderAddr)
+	}
+	return nil
+}
+
+// refreshLoop periodically calls Sync until Close stops the client.
+func (c *ClusterClient) refreshLoop() {
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), c.refreshInterval/2)
+			if err := c.Sync(ctx); err != nil {
+				log.Printf("[Client] background endpoint refresh failed: %v", err)
+			}
+			cancel()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// callWithFailover invokes fn against a target chosen per mode and, on a
+// transient Unavailable/DeadlineExceeded error, transparently retries
+// against another known endpoint before giving up, up to
+// maxFailoverAttempts. Any other error is returned to the caller as-is.
+func (c *ClusterClient) callWithFailover(ctx context.Context, mode BalancerMode, fn func(pb.ClusterServiceClient) error) error {
+	var lastErr error
+
+	for attempt := 0; attempt < c.maxFailoverAttempts; attempt++ {
+		addr, conn, err := c.resolver.next(mode)
+		if err != nil {
+			return err
+		}
+
+		err = fn(pb.NewClusterServiceClient(conn))
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		st := status.Convert(err)
+		if st.Code() != codes.Unavailable && st.Code() != codes.DeadlineExceeded {
+			return err
+		}
+
+		log.Printf("[Client] %s unreachable (%v); trying another endpoint", addr, err)
+		if mode == BalancerLeaderOnly {
+			c.resolver.clearLeader()
+		}
+	}
+
+	return fmt.Errorf("client: exhausted %d failover attempts: %w", c.maxFailoverAttempts, lastErr)
+}
+
+// streamClient returns a pb.ClusterServiceClient bound to a single target
+// chosen per mode, for the streaming RPCs (snapshot export/import) that
+// callWithFailover can't wrap since a stream can't be transparently
+// retried mid-flight once the caller has started sending or receiving.
+func (c *ClusterClient) streamClient(mode BalancerMode) (pb.ClusterServiceClient, error) {
+	_, conn, err := c.resolver.next(mode)
+	if err != nil {
+		return nil, err
+	}
+	return pb.NewClusterServiceClient(conn), nil
+}
+
 // ============================================================================
-// Write Operations (automatically redirected to leader by interceptor)
+// Write Operations (targeted at the leader, with redirect/failover backup)
 // ============================================================================
 
 // CreateCollection creates a new vector collection
 func (c *ClusterClient) CreateCollection(ctx context.Context, req *pb.CreateCollectionRequest) (*pb.CreateCollectionResponse, error) {
-	return c.client.CreateCollection(ctx, req)
+	var resp *pb.CreateCollectionResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.CreateCollection(ctx, req)
+		return err
+	})
+	return resp, err
 }
 
-// Insert inserts a vector into a collection
+// Insert inserts a vector into a collection. If the client was configured
+// with ClientConfig.BatchWindow, concurrent calls are transparently
+// coalesced into a single InsertBatch proposal; otherwise each call
+// proposes its own Raft entry.
 func (c *ClusterClient) Insert(ctx context.Context, req *pb.InsertRequest) (*pb.InsertResponse, error) {
-	return c.client.Insert(ctx, req)
+	if c.coalescer != nil {
+		return c.coalescer.Insert(ctx, req)
+	}
+
+	var resp *pb.InsertResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.Insert(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// InsertBatch proposes many points as a single Raft entry, avoiding a
+// full consensus round-trip per point during bulk ingestion. Partial
+// failures don't fail the whole batch: check InsertBatchResponse.Results
+// for each point's individual outcome.
+func (c *ClusterClient) InsertBatch(ctx context.Context, points []*pb.InsertRequest) (*pb.InsertBatchResponse, error) {
+	var resp *pb.InsertBatchResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.InsertBatch(ctx, &pb.InsertBatchRequest{Points: points})
+		return err
+	})
+	return resp, err
 }
 
 // Delete deletes a vector from a collection
 func (c *ClusterClient) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
-	return c.client.Delete(ctx, req)
+	var resp *pb.DeleteResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.Delete(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// DeleteBatch proposes many deletes as a single Raft entry, the
+// delete-side counterpart to InsertBatch. Partial failures don't fail the
+// whole batch: check DeleteBatchResponse.Results for each point's
+// individual outcome.
+func (c *ClusterClient) DeleteBatch(ctx context.Context, points []*pb.DeleteRequest) (*pb.DeleteBatchResponse, error) {
+	var resp *pb.DeleteBatchResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.DeleteBatch(ctx, &pb.DeleteBatchRequest{Points: points})
+		return err
+	})
+	return resp, err
 }
 
 // ============================================================================
-// Read Operations (can be served by any node)
+// Read Operations (load balanced across known nodes per cfg.Balancer)
 // ============================================================================
 
 // Search performs vector similarity search
 func (c *ClusterClient) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
-	return c.client.Search(ctx, req)
+	var resp *pb.SearchResponse
+	err := c.callWithFailover(ctx, c.balancer, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.Search(ctx, req)
+		return err
+	})
+	return resp, err
 }
 
 // CollectionExists checks if a collection exists
 func (c *ClusterClient) CollectionExists(ctx context.Context, req *pb.CollectionExistsRequest) (*pb.CollectionExistsResponse, error) {
-	return c.client.CollectionExists(ctx, req)
+	var resp *pb.CollectionExistsResponse
+	err := c.callWithFailover(ctx, c.balancer, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.CollectionExists(ctx, req)
+		return err
+	})
+	return resp, err
 }
 
 // ============================================================================
@@ -131,22 +396,141 @@ func (c *ClusterClient) CollectionExists(ctx context.Context, req *pb.Collection
 
 // GetClusterInfo retrieves cluster information
 func (c *ClusterClient) GetClusterInfo(ctx context.Context) (*pb.ClusterInfoResponse, error) {
-	return c.client.GetClusterInfo(ctx, &pb.GetClusterInfoRequest{})
+	var resp *pb.ClusterInfoResponse
+	err := c.callWithFailover(ctx, c.balancer, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.GetClusterInfo(ctx, &pb.GetClusterInfoRequest{})
+		return err
+	})
+	return resp, err
 }
 
-// JoinCluster requests to join the cluster
-func (c *ClusterClient) JoinCluster(ctx context.Context, nodeID, nodeAddr string) (*pb.JoinClusterResponse, error) {
-	return c.client.JoinCluster(ctx, &pb.JoinClusterRequest{
-		NodeId:   nodeID,
-		NodeAddr: nodeAddr,
+// JoinCluster requests to join the cluster. asLearner adds the node as a
+// non-voting learner (see ClusterServer.JoinCluster) instead of a full
+// voter, for safely catching up a node with a large amount of state to
+// replicate before it can affect quorum. protocolVersion/features are the
+// joining node's own advertised ClusterCapabilities, gossiped to the
+// leader and replicated cluster-wide so MinClusterCapability can account
+// for it; the leader refuses the join outright if protocolVersion falls
+// outside the window it supports.
+func (c *ClusterClient) JoinCluster(ctx context.Context, nodeID, nodeAddr string, asLearner bool, protocolVersion int32, features map[string]bool) (*pb.JoinClusterResponse, error) {
+	var resp *pb.JoinClusterResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.JoinCluster(ctx, &pb.JoinClusterRequest{
+			NodeId:          nodeID,
+			NodeAddr:        nodeAddr,
+			AsLearner:       asLearner,
+			ProtocolVersion: protocolVersion,
+			Features:        features,
+		})
+		return err
 	})
+	return resp, err
 }
 
 // LeaveCluster requests to leave the cluster
 func (c *ClusterClient) LeaveCluster(ctx context.Context, nodeID string) (*pb.LeaveClusterResponse, error) {
-	return c.client.LeaveCluster(ctx, &pb.LeaveClusterRequest{
-		NodeId: nodeID,
+	var resp *pb.LeaveClusterResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.LeaveCluster(ctx, &pb.LeaveClusterRequest{
+			NodeId: nodeID,
+		})
+		return err
+	})
+	return resp, err
+}
+
+// NodeStatus asks the connected node for its own Raft progress (see
+// ClusterServer.NodeStatus). Used by the promote CLI to read a learner's
+// applied index directly off the learner itself, rather than through the
+// leader it's about to be promoted on.
+func (c *ClusterClient) NodeStatus(ctx context.Context) (*pb.NodeStatusResponse, error) {
+	var resp *pb.NodeStatusResponse
+	err := c.callWithFailover(ctx, c.balancer, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.NodeStatus(ctx, &pb.NodeStatusRequest{})
+		return err
+	})
+	return resp, err
+}
+
+// PromoteCluster transitions a learner node to a full voter, provided its
+// appliedIndex is within maxLagIndex of the leader's log (see
+// ClusterServer.PromoteCluster).
+func (c *ClusterClient) PromoteCluster(ctx context.Context, nodeID, nodeAddr string, appliedIndex, maxLagIndex uint64) (*pb.PromoteClusterResponse, error) {
+	var resp *pb.PromoteClusterResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.PromoteCluster(ctx, &pb.PromoteClusterRequest{
+			NodeId:       nodeID,
+			NodeAddr:     nodeAddr,
+			AppliedIndex: appliedIndex,
+			MaxLagIndex:  maxLagIndex,
+		})
+		return err
+	})
+	return resp, err
+}
+
+// DemoteCluster shrinks the cluster safely, demoting nodeID to a
+// non-voter before removing it (see ClusterServer.DemoteCluster).
+func (c *ClusterClient) DemoteCluster(ctx context.Context, nodeID string) (*pb.DemoteClusterResponse, error) {
+	var resp *pb.DemoteClusterResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.DemoteCluster(ctx, &pb.DemoteClusterRequest{
+			NodeId: nodeID,
+		})
+		return err
+	})
+	return resp, err
+}
+
+// TransferLeadership asks the leader to hand off its role to nodeID, e.g.
+// ahead of a planned restart of the current leader (see
+// ClusterServer.TransferLeadership). nodeAddr may be left empty to have
+// the leader resolve it from its own Raft configuration.
+func (c *ClusterClient) TransferLeadership(ctx context.Context, nodeID, nodeAddr string) (*pb.TransferLeadershipResponse, error) {
+	var resp *pb.TransferLeadershipResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.TransferLeadership(ctx, &pb.TransferLeadershipRequest{NodeId: nodeID, NodeAddr: nodeAddr})
+		return err
+	})
+	return resp, err
+}
+
+// Drain asks the specific node this client is connected to (not
+// necessarily the leader) to prepare to leave the cluster: transfer away
+// leadership if it holds it, then remove itself via the new leader (see
+// ClusterServer.Drain). Callers should construct a ClusterClient pointed
+// directly at the node being drained, the same way NodeStatus is queried
+// directly off a learner rather than through leader failover.
+func (c *ClusterClient) Drain(ctx context.Context) (*pb.DrainResponse, error) {
+	var resp *pb.DrainResponse
+	err := c.callWithFailover(ctx, BalancerRoundRobin, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.Drain(ctx, &pb.DrainRequest{})
+		return err
+	})
+	return resp, err
+}
+
+// CreateBackup asks the leader to write an FSM snapshot to dest on its own
+// filesystem (see ClusterServer.CreateBackup), unlike SnapshotSave which
+// streams the snapshot to this client instead.
+func (c *ClusterClient) CreateBackup(ctx context.Context, dest string) (*pb.CreateBackupResponse, error) {
+	var resp *pb.CreateBackupResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.CreateBackup(ctx, &pb.CreateBackupRequest{Dest: dest})
+		return err
+	})
+	return resp, err
+}
+
+// RestoreBackup asks the leader to restore its FSM from src on its own
+// filesystem (see ClusterServer.RestoreBackup).
+func (c *ClusterClient) RestoreBackup(ctx context.Context, src string) (*pb.RestoreBackupResponse, error) {
+	var resp *pb.RestoreBackupResponse
+	err := c.callWithFailover(ctx, BalancerLeaderOnly, func(cl pb.ClusterServiceClient) (err error) {
+		resp, err = cl.RestoreBackup(ctx, &pb.RestoreBackupRequest{Src: src})
+		return err
 	})
+	return resp, err
 }
 
 // ============================================================================
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+)
+
+// BalancerMode selects how a ClusterClient picks a target endpoint for a
+// given call.
+type BalancerMode string
+
+const (
+	// BalancerRoundRobin cycles through all known endpoints. Used for
+	// reads, which any node in the cluster can serve.
+	BalancerRoundRobin BalancerMode = "round_robin"
+	// BalancerLeaderOnly always targets the cached leader. Used for
+	// writes, so they skip the redirect round-trip on the common path.
+	BalancerLeaderOnly BalancerMode = "leader_only"
+)
+
+// endpointResolver tracks the cluster's known endpoints and its current
+// leader, and hands out connections for round-robin reads or leader-only
+// writes. The leader address is kept warm by periodic GetClusterInfo
+// refreshes (see ClusterClient.Sync) and by leader hints fed in by
+// RedirectInterceptor as it observes redirects in the wild.
+type endpointResolver struct {
+	mu        sync.RWMutex
+	endpoints []string
+	conns     map[string]*grpc.ClientConn
+	dialOpts  []grpc.DialOption
+
+	leaderAddr atomic.Value // string
+	rrCounter  uint64
+}
+
+// newEndpointResolver creates a resolver seeded with the given endpoints.
+// Connections are dialed lazily, on first use, with dialOpts applied.
+func newEndpointResolver(seeds []string, dialOpts []grpc.DialOption) *endpointResolver {
+	r := &endpointResolver{
+		endpoints: append([]string(nil), seeds...),
+		conns:     make(map[string]*grpc.ClientConn),
+		dialOpts:  dialOpts,
+	}
+	r.leaderAddr.Store("")
+	return r
+}
+
+// connFor returns a cached connection to addr, dialing one if necessary.
+func (r *endpointResolver) connFor(addr string) (*grpc.ClientConn, error) {
+	r.mu.RLock()
+	if conn, ok := r.conns[addr]; ok {
+		r.mu.RUnlock()
+		return conn, nil
+	}
+	r.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if conn, ok := r.conns[addr]; ok {
+		return conn, nil
+	}
+
+	conn, err := grpc.Dial(addr, r.dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", addr, err)
+	}
+	r.conns[addr] = conn
+	return conn, nil
+}
+
+// dialBlocking connects to addr within the given context, failing fast if
+// the node is unreachable, and caches the resulting connection under addr
+// the same as connFor. Used only for the initial seed connection at
+// client construction, where a clear "couldn't connect" error up front is
+// more useful than discovering it on the first RPC.
+func (r *endpointResolver) dialBlocking(ctx context.Context, addr string) (*grpc.ClientConn, error) {
+	conn, err := grpc.DialContext(ctx, addr, append(append([]grpc.DialOption(nil), r.dialOpts...), grpc.WithBlock())...)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", addr, err)
+	}
+
+	r.mu.Lock()
+	r.conns[addr] = conn
+	r.mu.Unlock()
+	return conn, nil
+}
+
+// addEndpoint records addr as a known endpoint, if it isn't already.
+func (r *endpointResolver) addEndpoint(addr string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.endpoints {
+		if e == addr {
+			return
+		}
+	}
+	r.endpoints = append(r.endpoints, addr)
+}
+
+// snapshot returns the currently known endpoints.
+func (r *endpointResolver) snapshot() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.endpoints...)
+}
+
+// setLeader records addr as the current leader, also registering it as a
+// known endpoint so round-robin reads can reach it too.
+func (r *endpointResolver) setLeader(addr string) {
+	if addr == "" {
+		return
+	}
+	r.leaderAddr.Store(addr)
+	r.addEndpoint(addr)
+}
+
+// clearLeader forgets the cached leader, e.g. after a leader-only call
+// fails with Unavailable, so the next call re-discovers it.
+func (r *endpointResolver) clearLeader() {
+	r.leaderAddr.Store("")
+}
+
+func (r *endpointResolver) leader() string {
+	return r.leaderAddr.Load().(string)
+}
+
+// next picks a target per mode and returns its address and connection.
+// BalancerLeaderOnly prefers the cached leader, falling back to
+// round-robin if no leader is known yet.
+func (r *endpointResolver) next(mode BalancerMode) (string, *grpc.ClientConn, error) {
+	if mode == BalancerLeaderOnly {
+		if leader := r.leader(); leader != "" {
+			conn, err := r.connFor(leader)
+			if err == nil {
+				return leader, conn, nil
+			}
+		}
+	}
+
+	endpoints := r.snapshot()
+	if len(endpoints) == 0 {
+		return "", nil, errors.New("client: no known endpoints")
+	}
+	idx := atomic.AddUint64(&r.rrCounter, 1)
+	addr := endpoints[idx%uint64(len(endpoints))]
+	conn, err := r.connFor(addr)
+	if err != nil {
+		return "", nil, err
+	}
+	return addr, conn, nil
+}
+
+// close closes every connection this resolver has dialed.
+func (r *endpointResolver) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for addr, conn := range r.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", addr, err))
+		}
+	}
+	r.conns = make(map[string]*grpc.ClientConn)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors closing connections: %v", errs)
+	}
+	return nil
+}
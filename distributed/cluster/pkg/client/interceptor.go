@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/rand"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	pb "github.com/uttom-akash/vector-xlite/distributed/cluster/pkg/pb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
@@ -13,22 +17,129 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// RedirectInterceptor handles automatic redirection to leader
+// RetryPolicy configures how RedirectInterceptor retries a call that
+// fails with codes.Unavailable (e.g. "no leader available, please
+// retry", returned for the whole span of a Raft election) instead of
+// returning that error to the caller immediately.
+type RetryPolicy struct {
+	// MaxRetries is how many additional attempts to make after the
+	// original failed call. Zero disables retry.
+	MaxRetries int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between any two retries; backoff doubles
+	// on every attempt until it hits this ceiling.
+	MaxBackoff time.Duration
+
+	// Jitter randomizes each backoff by up to this fraction in either
+	// direction (e.g. 0.2 varies it by ±20%), so a fleet of clients that
+	// all observed the same leader crash don't retry in lockstep.
+	Jitter float64
+}
+
+// DefaultRetryPolicy is used when NewRedirectInterceptor isn't given one.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Jitter:         0.2,
+	}
+}
+
+// backoff computes the delay before retry attempt (1-indexed) under p,
+// including jitter.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff << uint(attempt-1)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		spread := float64(d) * p.Jitter
+		d += time.Duration((rand.Float64()*2 - 1) * spread)
+		if d < 0 {
+			d = 0
+		}
+	}
+	return d
+}
+
+// RetryEvent describes one RedirectInterceptor retry, reported through
+// onRetry for LoggingInterceptor (or any other observer) to record.
+type RetryEvent struct {
+	Method   string
+	Attempt  int
+	MaxRetry int
+	Backoff  time.Duration
+	Cause    error
+	// ReResolvedLeader is the leader address a seed reported for this
+	// retry, or empty if no seed could be reached or none was queried.
+	ReResolvedLeader string
+}
+
+// RedirectInterceptor handles automatic redirection to leader, plus
+// transparent retry-with-backoff while no leader is available at all
+// (e.g. mid-election).
 type RedirectInterceptor struct {
-	maxRedirects  int
-	connCache     map[string]*grpc.ClientConn
-	connCacheMux  sync.RWMutex
+	maxRedirects int
+	connCache    map[string]*grpc.ClientConn
+	connCacheMux sync.RWMutex
+
+	// dialOpts is used to open every connection this interceptor caches,
+	// so a freshly-discovered leader is dialed with the same transport
+	// credentials and AuthInterceptor as the rest of the client instead
+	// of falling back to plaintext, which would silently drop TLS/auth on
+	// every retried call.
+	dialOpts []grpc.DialOption
+
+	// seeds is the address list handed to NewRedirectInterceptor, queried
+	// round-robin via GetClusterInfo between retries to re-resolve the
+	// leader. Kept separate from a ClusterClient's endpointResolver:
+	// re-resolution here must work even when the node a call was
+	// originally dialed against is the one that crashed and triggered the
+	// election.
+	seeds   []string
+	seedIdx uint64
+	retry   RetryPolicy
+
+	// onLeaderHint, if set, is called with every leader address this
+	// interceptor learns about from a FailedPrecondition redirect
+	// response or a retry's re-resolution, so a ClusterClient's endpoint
+	// resolver can cache it and skip the redirect round-trip on the next
+	// leader-only call.
+	onLeaderHint func(addr string)
+
+	// onRetry, if set, is called once per retry attempt, so a
+	// LoggingInterceptor can report it alongside every other call.
+	onRetry func(RetryEvent)
 }
 
-// NewRedirectInterceptor creates a new redirect interceptor
-func NewRedirectInterceptor(maxRedirects int) *RedirectInterceptor {
+// NewRedirectInterceptor creates a new redirect interceptor. seeds is
+// queried via GetClusterInfo to re-resolve the leader between retries
+// (see RetryPolicy); a nil retry defaults to DefaultRetryPolicy(). dialOpts
+// is used to open connections to newly-discovered leaders and seeds; if
+// empty, it defaults to plaintext (insecure.NewCredentials()), matching
+// this interceptor's behavior before TLS/auth support existed.
+func NewRedirectInterceptor(maxRedirects int, seeds []string, retry *RetryPolicy, dialOpts ...grpc.DialOption) *RedirectInterceptor {
 	if maxRedirects <= 0 {
 		maxRedirects = 3
 	}
+	if len(dialOpts) == 0 {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	policy := DefaultRetryPolicy()
+	if retry != nil {
+		policy = *retry
+	}
 
 	return &RedirectInterceptor{
 		maxRedirects: maxRedirects,
 		connCache:    make(map[string]*grpc.ClientConn),
+		dialOpts:     dialOpts,
+		seeds:        append([]string(nil), seeds...),
+		retry:        policy,
 	}
 }
 
@@ -42,10 +153,103 @@ func (i *RedirectInterceptor) Unary() grpc.UnaryClientInterceptor {
 		invoker grpc.UnaryInvoker,
 		opts ...grpc.CallOption,
 	) error {
-		return i.invokeWithRedirect(ctx, method, req, reply, cc, invoker, opts, 0)
+		return i.invokeWithRetry(ctx, method, req, reply, cc, invoker, opts)
 	}
 }
 
+// invokeWithRetry wraps invokeWithRedirect with exponential-backoff retry
+// on codes.Unavailable (and codes.DeadlineExceeded, which the server
+// returns the same way while an election is still in flight), re-
+// resolving the leader off i.seeds between attempts so recovery doesn't
+// depend on the originally dialed node - possibly the one that crashed
+// and triggered the election - coming back.
+func (i *RedirectInterceptor) invokeWithRetry(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts []grpc.CallOption,
+) error {
+	target := cc
+
+	for attempt := 0; ; attempt++ {
+		err := i.invokeWithRedirect(ctx, method, req, reply, target, invoker, opts, 0)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= i.retry.MaxRetries || ctx.Err() != nil || !isRetryableUnavailable(err) {
+			return err
+		}
+
+		backoff := i.retry.backoff(attempt + 1)
+		event := RetryEvent{Method: method, Attempt: attempt + 1, MaxRetry: i.retry.MaxRetries, Backoff: backoff, Cause: err}
+
+		if leaderAddr, rerr := i.reresolveLeader(ctx); rerr == nil && leaderAddr != "" {
+			event.ReResolvedLeader = leaderAddr
+			if conn, cerr := i.getOrCreateConnection(leaderAddr); cerr == nil {
+				target = conn
+				if i.onLeaderHint != nil {
+					i.onLeaderHint(leaderAddr)
+				}
+			}
+		}
+
+		if i.onRetry != nil {
+			i.onRetry(event)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryableUnavailable reports whether err is the kind of "no leader
+// available, please retry" failure worth backing off and retrying.
+func isRetryableUnavailable(err error) bool {
+	code := status.Convert(err).Code()
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// nextSeed rotates through the seed addresses supplied at construction,
+// so repeated retries spread their GetClusterInfo queries across all of
+// them instead of hammering whichever one answered first.
+func (i *RedirectInterceptor) nextSeed() (string, bool) {
+	if len(i.seeds) == 0 {
+		return "", false
+	}
+	idx := atomic.AddUint64(&i.seedIdx, 1) - 1
+	return i.seeds[idx%uint64(len(i.seeds))], true
+}
+
+// reresolveLeader asks the next seed address for the cluster's current
+// leader via GetClusterInfo - the same RPC ClusterClient.Sync uses for
+// its periodic background refresh - queried directly here since
+// RedirectInterceptor has no reference back to a ClusterClient.
+func (i *RedirectInterceptor) reresolveLeader(ctx context.Context) (string, error) {
+	seed, ok := i.nextSeed()
+	if !ok {
+		return "", fmt.Errorf("redirect: no seed addresses configured to re-resolve leader")
+	}
+
+	seedConn, err := i.getOrCreateConnection(seed)
+	if err != nil {
+		return "", fmt.Errorf("redirect: dial seed %s: %w", seed, err)
+	}
+
+	info, err := pb.NewClusterServiceClient(seedConn).GetClusterInfo(ctx, &pb.GetClusterInfoRequest{})
+	if err != nil {
+		return "", fmt.Errorf("redirect: query seed %s: %w", seed, err)
+	}
+	return info.LeaderAddr, nil
+}
+
 // invokeWithRedirect recursively handles redirects
 func (i *RedirectInterceptor) invokeWithRedirect(
 	ctx context.Context,
@@ -82,6 +286,10 @@ func (i *RedirectInterceptor) invokeWithRedirect(
 				leaderAddr := leaderAddrs[0]
 				log.Printf("[Redirect] Redirecting to leader: %s (attempt %d)", leaderAddr, redirectCount+1)
 
+				if i.onLeaderHint != nil {
+					i.onLeaderHint(leaderAddr)
+				}
+
 				// Get or create connection to leader
 				leaderConn, err := i.getOrCreateConnection(leaderAddr)
 				if err != nil {
@@ -126,10 +334,7 @@ func (i *RedirectInterceptor) getOrCreateConnection(addr string) (*grpc.ClientCo
 	}
 
 	// Create new connection
-	conn, err := grpc.Dial(
-		addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	conn, err := grpc.Dial(addr, i.dialOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -196,3 +401,16 @@ func (i *LoggingInterceptor) Unary() grpc.UnaryClientInterceptor {
 		return err
 	}
 }
+
+// LogRetry reports one RedirectInterceptor retry (see RetryEvent), so a
+// flapping leader's retries show up in the same log stream as every
+// other call this interceptor logs.
+func (i *LoggingInterceptor) LogRetry(ev RetryEvent) {
+	if ev.ReResolvedLeader != "" {
+		log.Printf("[Client] Retry: method=%s attempt=%d/%d backoff=%s leader=%s cause=%v",
+			ev.Method, ev.Attempt, ev.MaxRetry, ev.Backoff, ev.ReResolvedLeader, ev.Cause)
+		return
+	}
+	log.Printf("[Client] Retry: method=%s attempt=%d/%d backoff=%s cause=%v",
+		ev.Method, ev.Attempt, ev.MaxRetry, ev.Backoff, ev.Cause)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/raft"
+	pb "github.com/uttom-akash/vector-xlite/distributed/cluster/pkg/pb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -32,8 +33,22 @@ func (i *LeaderRedirectInterceptor) Unary() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		// Check if this is a write operation that requires leadership
-		if isWriteOperation(info.FullMethod) {
+		// Check if this method's policy requires leadership, or a Search
+		// request asking for strong/leader consistency (which also requires
+		// leadership, since only the leader can guarantee it).
+		requiresLeader := policyFor(info.FullMethod) == PolicyLeader
+		if !requiresLeader {
+			if searchReq, ok := req.(*pb.SearchRequest); ok {
+				// "LeaderOnly" is a friendlier alias for "Leader"/"Strong",
+				// both of which already mean "always redirect to the
+				// leader" — accepted alongside them so older and newer
+				// callers of SearchRequest.Consistency both work.
+				requiresLeader = searchReq.Consistency == "Strong" ||
+					searchReq.Consistency == "Leader" ||
+					searchReq.Consistency == "LeaderOnly"
+			}
+		}
+		if requiresLeader {
 			// Check if this node is the leader
 			if i.raftNode.State() != raft.Leader {
 				leaderRaftAddr := string(i.raftNode.Leader())
@@ -48,7 +63,7 @@ func (i *LeaderRedirectInterceptor) Unary() grpc.UnaryServerInterceptor {
 
 				// Convert raft address (xxx1) to cluster address (xxx2)
 				// Example: "127.0.0.1:5001" -> "127.0.0.1:5002"
-				leaderClusterAddr, err := convertRaftToClusterAddr(leaderRaftAddr)
+				leaderClusterAddr, err := ConvertRaftToClusterAddr(leaderRaftAddr)
 				if err != nil {
 					return nil, status.Errorf(
 						codes.Internal,
@@ -79,9 +94,43 @@ func (i *LeaderRedirectInterceptor) Unary() grpc.UnaryServerInterceptor {
 	}
 }
 
-// convertRaftToClusterAddr converts raft address (xxx1) to cluster address (xxx2)
+// Stream returns the stream server interceptor, the streaming-call
+// counterpart to Unary: it runs the same leader check up front, before
+// handing off to handler, since a streaming RPC like BulkInsert can't be
+// redirected mid-stream once the client has started sending.
+func (i *LeaderRedirectInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if policyFor(info.FullMethod) == PolicyLeader && i.raftNode.State() != raft.Leader {
+			leaderRaftAddr := string(i.raftNode.Leader())
+			if leaderRaftAddr == "" {
+				return status.Errorf(codes.Unavailable, "no leader available, please retry")
+			}
+
+			leaderClusterAddr, err := ConvertRaftToClusterAddr(leaderRaftAddr)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to convert leader address: %v", err)
+			}
+
+			md := metadata.Pairs("x-leader-addr", leaderClusterAddr, "x-redirect", "true")
+			if err := ss.SetHeader(md); err != nil {
+				return status.Errorf(codes.Internal, "failed to set header: %v", err)
+			}
+
+			return status.Errorf(codes.FailedPrecondition, "not leader, redirect to: %s", leaderClusterAddr)
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// ConvertRaftToClusterAddr converts raft address (xxx1) to cluster address (xxx2)
 // Example: "127.0.0.1:5001" -> "127.0.0.1:5002"
-func convertRaftToClusterAddr(raftAddr string) (string, error) {
+func ConvertRaftToClusterAddr(raftAddr string) (string, error) {
 	// Split address into host and port
 	lastColon := strings.LastIndex(raftAddr, ":")
 	if lastColon == -1 {
@@ -103,17 +152,49 @@ func convertRaftToClusterAddr(raftAddr string) (string, error) {
 	return clusterAddr, nil
 }
 
-// isWriteOperation checks if the given gRPC method requires leader
-func isWriteOperation(method string) bool {
-	writeOperations := map[string]bool{
-		"/vectorxlite.cluster.ClusterService/CreateCollection": true,
-		"/vectorxlite.cluster.ClusterService/Insert":           true,
-		"/vectorxlite.cluster.ClusterService/Delete":           true,
-		"/vectorxlite.cluster.ClusterService/JoinCluster":      true,
-		"/vectorxlite.cluster.ClusterService/LeaveCluster":     true,
-	}
+// MethodPolicy classifies how strictly a gRPC method's consistency must be
+// enforced by LeaderRedirectInterceptor.
+type MethodPolicy int
+
+const (
+	// PolicyLeader requires this node to be the Raft leader, redirecting
+	// otherwise. Used for all writes.
+	PolicyLeader MethodPolicy = iota
+	// PolicyLinearizable allows any node to serve the method, provided it
+	// first satisfies a ReadIndex barrier (see ClusterServer.Search's
+	// "Linearizable" case). The interceptor itself doesn't enforce this —
+	// the handler does — it only exempts the method from PolicyLeader.
+	PolicyLinearizable
+	// PolicyStale allows any node to serve the method immediately, with no
+	// consistency barrier.
+	PolicyStale
+)
+
+// methodPolicies maps each gRPC method to its MethodPolicy. Methods not
+// listed default to PolicyStale via policyFor.
+var methodPolicies = map[string]MethodPolicy{
+	"/vectorxlite.cluster.ClusterService/CreateCollection":   PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/Insert":             PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/InsertBatch":        PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/Delete":             PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/DeleteBatch":        PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/JoinCluster":        PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/LeaveCluster":       PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/PromoteCluster":     PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/DemoteCluster":      PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/TransferLeadership": PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/Search":             PolicyLinearizable,
+	"/vectorxlite.cluster.ClusterService/BulkInsert":         PolicyLeader,
+	"/vectorxlite.cluster.ClusterService/NodeStatus":         PolicyStale,
+}
 
-	return writeOperations[method]
+// policyFor returns method's configured MethodPolicy, defaulting to
+// PolicyStale for any method not explicitly listed in methodPolicies.
+func policyFor(method string) MethodPolicy {
+	if policy, ok := methodPolicies[method]; ok {
+		return policy
+	}
+	return PolicyStale
 }
 
 // LoggingInterceptor logs all incoming requests
@@ -146,3 +227,25 @@ func (i *LoggingInterceptor) Unary() grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// Stream returns the stream server interceptor for logging.
+func (i *LoggingInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		fmt.Printf("[gRPC] Stream: %s\n", info.FullMethod)
+
+		err := handler(srv, ss)
+
+		if err != nil {
+			fmt.Printf("[gRPC] Stream: %s, Error: %v\n", info.FullMethod, err)
+		} else {
+			fmt.Printf("[gRPC] Stream: %s, Success\n", info.FullMethod)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// LagTracker approximates how far this node trails the leader by the time,
+// in milliseconds, since it last applied a Raft entry, so BoundedStaleness
+// reads can decide locally whether to serve or forward to the leader. It
+// does not compare applied and committed indexes.
+type LagTracker struct {
+	lastAppliedAt int64 // unix nano, set whenever Apply observes a new entry
+}
+
+// NewLagTracker creates an empty tracker.
+func NewLagTracker() *LagTracker {
+	return &LagTracker{}
+}
+
+// RecordApplied marks that an entry was just applied locally.
+func (t *LagTracker) RecordApplied(now time.Time) {
+	atomic.StoreInt64(&t.lastAppliedAt, now.UnixNano())
+}
+
+// StalenessMs returns how long it has been since the last locally-applied
+// entry, as a proxy for replication lag behind the leader.
+func (t *LagTracker) StalenessMs(now time.Time) int64 {
+	last := atomic.LoadInt64(&t.lastAppliedAt)
+	if last == 0 {
+		return 0
+	}
+	return now.Sub(time.Unix(0, last)).Milliseconds()
+}
+
+// WithinBound reports whether the current staleness is within maxLagMs.
+func (t *LagTracker) WithinBound(maxLagMs int64, now time.Time) bool {
+	if maxLagMs <= 0 {
+		return true
+	}
+	return t.StalenessMs(now) <= maxLagMs
+}
@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"time"
 
@@ -13,15 +15,59 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// defaultBulkInsertBatchCount and defaultBulkInsertBatchBytes bound how
+// many BulkInsert stream messages ClusterServer.BulkInsert coalesces into
+// one onBulkInsert call when ClusterServerConfig doesn't override them.
+// Kept comfortably under ChunkingApplier's defaultMaxCommandBytes so a
+// coalesced batch normally proposes as a single Raft entry.
+const (
+	defaultBulkInsertBatchCount = 500
+	defaultBulkInsertBatchBytes = 256 * 1024
+)
+
+// defaultLearnerLagThreshold is how many log entries behind the leader's
+// last index a Nonvoter may be before GetClusterInfo reports it as
+// "Learner-Catching-Up" instead of "Nonvoter", when ClusterServerConfig
+// doesn't override it.
+const defaultLearnerLagThreshold = 1000
+
+// healthCheckTimeout bounds how long JoinCluster waits for its dial-back
+// health probe to a prospective member before giving up on it.
+const healthCheckTimeout = 5 * time.Second
+
 // ClusterNode interface to abstract Raft operations
 type ClusterNode interface {
 	// Raft operations
 	State() raft.RaftState
 	Leader() raft.ServerAddress
 	AddVoter(id raft.ServerID, address raft.ServerAddress, prevIndex uint64, timeout time.Duration) raft.IndexFuture
+	// AddNonvoter adds a server as a non-voting learner, which replicates
+	// the log without counting toward quorum until it's promoted with
+	// AddVoter.
+	AddNonvoter(id raft.ServerID, address raft.ServerAddress, prevIndex uint64, timeout time.Duration) raft.IndexFuture
+	// DemoteVoter demotes a voter to a non-voter in place, the first step
+	// of the demote-then-remove dance used to shrink the cluster safely.
+	DemoteVoter(id raft.ServerID, prevIndex uint64, timeout time.Duration) raft.IndexFuture
 	RemoveServer(id raft.ServerID, prevIndex uint64, timeout time.Duration) raft.IndexFuture
+	// LeadershipTransfer asks this node (which must be leader) to hand off
+	// leadership to the server identified by id/address, used by Drain so
+	// an evicted leader doesn't have to wait out an election timeout before
+	// the rest of the cluster notices it's gone.
+	LeadershipTransfer(id raft.ServerID, address raft.ServerAddress) raft.Future
 	Apply(cmd []byte, timeout time.Duration) raft.ApplyFuture
 	GetConfiguration() raft.ConfigurationFuture
+
+	// ReadIndex returns the Raft log index a linearizable read must locally
+	// apply through before it's safe to serve, following the leader via a
+	// heartbeat round (if this node is leader) or forwarding to it
+	// (otherwise).
+	ReadIndex(ctx context.Context) (uint64, error)
+	// LastApplied returns the Raft log index this node's FSM has applied
+	// through, so a caller can tell when it's caught up to a ReadIndex.
+	LastApplied() uint64
+	// LastIndex returns the last index in this node's Raft log, used here
+	// to compute a learner's replication lag against the leader's log.
+	LastIndex() uint64
 }
 
 // ClusterServer implements the ClusterService gRPC service
@@ -31,38 +77,212 @@ type ClusterServer struct {
 	raftNode ClusterNode
 	nodeID   string
 	nodeAddr string
+	lag      *LagTracker
+
+	// bulkInsertMaxBatchCount/Bytes bound how many BulkInsert stream
+	// messages BulkInsert coalesces before flushing a batch early.
+	bulkInsertMaxBatchCount int
+	bulkInsertMaxBatchBytes int
+
+	// learnerLagThreshold is how far behind the leader's LastIndex a
+	// Nonvoter's applied index may be before GetClusterInfo still calls it
+	// "Learner-Catching-Up" rather than "Nonvoter".
+	learnerLagThreshold uint64
+	// probeNodeStatus fetches a peer's current NodeStatus (by dialing its
+	// cluster address), used by GetClusterInfo to classify learners. Nil
+	// on a server that wasn't wired up with one, in which case every
+	// Nonvoter is reported as "Nonvoter" without a catching-up distinction.
+	probeNodeStatus func(ctx context.Context, nodeAddr string) (*pb.NodeStatusResponse, error)
+
+	// onWaitForIndex, if set, blocks until the local FSM has applied
+	// through a given index (e.g. consensus.ReadIndexWaiter.Wait), the
+	// way Linearizable Search honors its ReadIndex barrier. Nil falls
+	// back to waitForApplied's poll loop.
+	onWaitForIndex func(ctx context.Context, index uint64) error
+
+	// onHealthCheckNode, if set, is dialed against a prospective member's
+	// NodeAddr before JoinCluster adds it to the Raft configuration, so a
+	// typo'd address or a node that crashed between JoinCluster requests
+	// is rejected up front instead of being added as a peer Raft can never
+	// reach. Nil skips the probe entirely.
+	onHealthCheckNode func(ctx context.Context, nodeAddr string) error
+
+	// minSupportedProtocolVersion/maxSupportedProtocolVersion bound the
+	// ProtocolVersion JoinCluster accepts from a newcomer, so a node
+	// running a protocol this build predates (or one too old for it to
+	// talk to) is refused instead of silently admitted.
+	minSupportedProtocolVersion int32
+	maxSupportedProtocolVersion int32
+	// onRegisterCapabilities replicates a newly-joined node's advertised
+	// ClusterCapabilities into the FSM (see consensus.CmdRegisterCapabilities),
+	// so every member's MinClusterCapability agrees on it. Nil disables
+	// capability gossip entirely, in which case gated commands are judged
+	// solely against whatever capabilities already exist in the FSM.
+	onRegisterCapabilities func(ctx context.Context, nodeID string, protocolVersion int32, features map[string]bool) error
+	// onGetMinClusterCapability reports the cluster-wide minimum
+	// capability set, for GetClusterInfo to surface to the info CLI. Nil
+	// omits MinCapabilities from the response.
+	onGetMinClusterCapability func(ctx context.Context) (*pb.ClusterCapabilities, error)
 
 	// Callbacks for write operations
 	onCreateCollection func(ctx context.Context, req *pb.CreateCollectionRequest) error
 	onInsert           func(ctx context.Context, req *pb.InsertRequest) error
+	onBatchInsert      func(ctx context.Context, req *pb.InsertBatchRequest) ([]*pb.InsertResult, error)
+	onBulkInsert       func(ctx context.Context, reqs []*pb.InsertRequest) ([]*pb.InsertResult, error)
 	onDelete           func(ctx context.Context, req *pb.DeleteRequest) error
+	onDeleteBatch      func(ctx context.Context, req *pb.DeleteBatchRequest) ([]*pb.DeleteResult, error)
 	onSearch           func(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error)
+
+	// onWatch, if set, streams WatchEvents matching req to send until
+	// stream.Context() is done, blocking in between as needed (e.g.
+	// consensus.WatchHub.WaitForMore). Nil makes Watch return
+	// Unimplemented.
+	onWatch func(ctx context.Context, req *pb.WatchRequest, send func(*pb.WatchEvent) error) error
+
+	// onCreateBackup/onRestoreBackup trigger an out-of-band FSM snapshot
+	// export to, or import from, an operator-supplied destination (a local
+	// path or, eventually, an S3-compatible URL), independent of Raft's own
+	// snapshot/InstallSnapshot cycle. Nil makes CreateBackup/RestoreBackup
+	// return Unimplemented.
+	onCreateBackup  func(ctx context.Context, dest string) error
+	onRestoreBackup func(ctx context.Context, src string) error
+
+	// onLeaveClusterSelf, if set, is called by Drain after transferring
+	// away leadership to ask the cluster (now led by someone else) to
+	// remove this node, so draining doesn't require the operator to run a
+	// second command against whichever node ends up leader. Nil leaves
+	// that final LeaveCluster call to the operator.
+	onLeaveClusterSelf func(ctx context.Context) error
 }
 
 // ClusterServerConfig holds configuration for the ClusterServer
 type ClusterServerConfig struct {
 	RaftNode ClusterNode
-	// NodeID             string
-	// NodeAddr           string
+	// NodeID/NodeAddr identify this node to itself, used by Drain to tell
+	// a candidate leadership-transfer target apart from self and to issue
+	// its own LeaveCluster call against the newly transferred-to leader.
+	NodeID   string
+	NodeAddr string
+
+	// BulkInsertMaxBatchCount/Bytes bound how many points/bytes
+	// BulkInsert's stream coalesces into one OnBulkInsert call before
+	// flushing early, even if the client hasn't finished sending. Zero
+	// uses defaultBulkInsertBatchCount/Bytes.
+	BulkInsertMaxBatchCount int
+	BulkInsertMaxBatchBytes int
+
+	// LearnerLagThreshold overrides defaultLearnerLagThreshold (zero keeps
+	// the default).
+	LearnerLagThreshold uint64
+	// ProbeNodeStatus lets GetClusterInfo ask a learner node for its own
+	// NodeStatus, to tell "Nonvoter" and "Learner-Catching-Up" apart. Left
+	// nil, every Nonvoter is reported as "Nonvoter".
+	ProbeNodeStatus func(ctx context.Context, nodeAddr string) (*pb.NodeStatusResponse, error)
+
+	// OnWaitForIndex, if set, blocks until the local FSM has applied
+	// through a given index instead of Search polling LastApplied on a
+	// timer to find out (see consensus.ReadIndexWaiter.Wait).
+	OnWaitForIndex func(ctx context.Context, index uint64) error
+
+	// OnHealthCheckNode, if set, is dialed against a prospective member's
+	// NodeAddr before JoinCluster adds it to the Raft configuration (see
+	// ClusterServer.onHealthCheckNode). Left nil, JoinCluster adds the
+	// node without first confirming it's reachable.
+	OnHealthCheckNode func(ctx context.Context, nodeAddr string) error
+
+	// MinSupportedProtocolVersion/MaxSupportedProtocolVersion bound the
+	// ProtocolVersion JoinCluster accepts; both zero disables the check
+	// (any ProtocolVersion is accepted), for a server wired up without
+	// capability negotiation.
+	MinSupportedProtocolVersion int32
+	MaxSupportedProtocolVersion int32
+	// OnRegisterCapabilities, if set, is called after a successful join to
+	// replicate the newcomer's advertised capabilities into the FSM.
+	OnRegisterCapabilities func(ctx context.Context, nodeID string, protocolVersion int32, features map[string]bool) error
+	// OnGetMinClusterCapability, if set, lets GetClusterInfo report the
+	// cluster-wide minimum capability set.
+	OnGetMinClusterCapability func(ctx context.Context) (*pb.ClusterCapabilities, error)
+
 	OnCreateCollection func(ctx context.Context, req *pb.CreateCollectionRequest) error
 	OnInsert           func(ctx context.Context, req *pb.InsertRequest) error
+	OnBatchInsert      func(ctx context.Context, req *pb.InsertBatchRequest) ([]*pb.InsertResult, error)
+	OnBulkInsert       func(ctx context.Context, reqs []*pb.InsertRequest) ([]*pb.InsertResult, error)
 	OnDelete           func(ctx context.Context, req *pb.DeleteRequest) error
-	OnSearch           func(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error)
+	// OnDeleteBatch proposes many deletes as a single Raft entry, the
+	// delete-side counterpart to OnBatchInsert.
+	OnDeleteBatch func(ctx context.Context, req *pb.DeleteBatchRequest) ([]*pb.DeleteResult, error)
+	OnSearch      func(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error)
+
+	// OnWatch, if set, streams WatchEvents matching a WatchRequest to the
+	// given send func (see consensus.WatchHub). Left nil, Watch returns
+	// Unimplemented.
+	OnWatch func(ctx context.Context, req *pb.WatchRequest, send func(*pb.WatchEvent) error) error
+
+	// OnCreateBackup/OnRestoreBackup back CreateBackup/RestoreBackup (see
+	// consensus.VxFSM.Snapshot/Restore). Left nil, both RPCs return
+	// Unimplemented.
+	OnCreateBackup  func(ctx context.Context, dest string) error
+	OnRestoreBackup func(ctx context.Context, src string) error
+
+	// OnLeaveClusterSelf, if set, is dialed against the cluster's current
+	// leader by Drain once this node has transferred leadership away, to
+	// call LeaveCluster(NodeID) on this node's behalf. Left nil, Drain
+	// stops after transferring leadership and the operator is expected to
+	// remove the node with a separate 'demote'/'leave' call.
+	OnLeaveClusterSelf func(ctx context.Context) error
 }
 
 // NewClusterServer creates a new ClusterServer instance
 func NewClusterServer(cfg ClusterServerConfig) *ClusterServer {
+	maxBatchCount := cfg.BulkInsertMaxBatchCount
+	if maxBatchCount <= 0 {
+		maxBatchCount = defaultBulkInsertBatchCount
+	}
+	maxBatchBytes := cfg.BulkInsertMaxBatchBytes
+	if maxBatchBytes <= 0 {
+		maxBatchBytes = defaultBulkInsertBatchBytes
+	}
+	lagThreshold := cfg.LearnerLagThreshold
+	if lagThreshold == 0 {
+		lagThreshold = defaultLearnerLagThreshold
+	}
+
 	return &ClusterServer{
-		raftNode: cfg.RaftNode,
-		// nodeID:             cfg.NodeID,
-		// nodeAddr:           cfg.NodeAddr,
-		onCreateCollection: cfg.OnCreateCollection,
-		onInsert:           cfg.OnInsert,
-		onDelete:           cfg.OnDelete,
-		onSearch:           cfg.OnSearch,
+		raftNode:                     cfg.RaftNode,
+		nodeID:                       cfg.NodeID,
+		nodeAddr:                     cfg.NodeAddr,
+		lag:                          NewLagTracker(),
+		bulkInsertMaxBatchCount:      maxBatchCount,
+		bulkInsertMaxBatchBytes:      maxBatchBytes,
+		learnerLagThreshold:          lagThreshold,
+		probeNodeStatus:              cfg.ProbeNodeStatus,
+		onWaitForIndex:               cfg.OnWaitForIndex,
+		onHealthCheckNode:            cfg.OnHealthCheckNode,
+		minSupportedProtocolVersion:  cfg.MinSupportedProtocolVersion,
+		maxSupportedProtocolVersion:  cfg.MaxSupportedProtocolVersion,
+		onRegisterCapabilities:       cfg.OnRegisterCapabilities,
+		onGetMinClusterCapability:    cfg.OnGetMinClusterCapability,
+		onCreateCollection:           cfg.OnCreateCollection,
+		onInsert:                     cfg.OnInsert,
+		onBatchInsert:                cfg.OnBatchInsert,
+		onBulkInsert:                 cfg.OnBulkInsert,
+		onDelete:                     cfg.OnDelete,
+		onDeleteBatch:                cfg.OnDeleteBatch,
+		onSearch:                     cfg.OnSearch,
+		onWatch:                      cfg.OnWatch,
+		onCreateBackup:               cfg.OnCreateBackup,
+		onRestoreBackup:              cfg.OnRestoreBackup,
+		onLeaveClusterSelf:           cfg.OnLeaveClusterSelf,
 	}
 }
 
+// RecordApplied should be called by the owning node whenever a Raft entry is
+// applied locally, so the server's LagTracker can answer BoundedStaleness
+// checks without the search path reaching back into Raft internals.
+func (s *ClusterServer) RecordApplied(now time.Time) {
+	s.lag.RecordApplied(now)
+}
+
 // isLeader checks if this node is the leader
 func (s *ClusterServer) isLeader() bool {
 	return s.raftNode.State() == raft.Leader
@@ -110,6 +330,97 @@ func (s *ClusterServer) Insert(ctx context.Context, req *pb.InsertRequest) (*pb.
 	}, nil
 }
 
+// InsertBatch handles bulk vector insertion (write operation), proposing
+// all points as a single Raft entry (possibly chunked across several log
+// entries if oversized; see consensus.ChunkingApplier).
+// Note: Leadership check is handled by LeaderRedirectInterceptor.
+func (s *ClusterServer) InsertBatch(ctx context.Context, req *pb.InsertBatchRequest) (*pb.InsertBatchResponse, error) {
+	if s.onBatchInsert == nil {
+		return &pb.InsertBatchResponse{}, nil
+	}
+
+	results, err := s.onBatchInsert(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.InsertBatchResponse{Results: results}, nil
+}
+
+// BulkInsert handles a client-streaming bulk load (write operation): the
+// caller sends many InsertRequest messages on one stream instead of one
+// RPC per point, and this handler coalesces them into batches of up to
+// bulkInsertMaxBatchCount points or bulkInsertMaxBatchBytes, proposing
+// each batch as a single CmdBulkInsert Raft entry via onBulkInsert so a
+// batch pays one fsync instead of one per point. A final
+// BulkInsertResponse summarizing every point's outcome is sent once the
+// client closes its send side.
+// Note: Leadership check is handled by LeaderRedirectInterceptor.
+func (s *ClusterServer) BulkInsert(stream pb.ClusterService_BulkInsertServer) error {
+	ctx := stream.Context()
+
+	var batch []*pb.InsertRequest
+	batchBytes := 0
+	var results []*pb.InsertResult
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if s.onBulkInsert != nil {
+			batchResults, err := s.onBulkInsert(ctx, batch)
+			if err != nil {
+				return err
+			}
+			results = append(results, batchResults...)
+		}
+		batch = nil
+		batchBytes = 0
+		return nil
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "receive insert request: %v", err)
+		}
+
+		reqBytes, err := json.Marshal(req)
+		if err != nil {
+			return status.Errorf(codes.Internal, "estimate request size: %v", err)
+		}
+
+		batch = append(batch, req)
+		batchBytes += len(reqBytes)
+		if len(batch) >= s.bulkInsertMaxBatchCount || batchBytes >= s.bulkInsertMaxBatchBytes {
+			if err := flush(); err != nil {
+				return status.Errorf(codes.Internal, "apply bulk insert batch: %v", err)
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return status.Errorf(codes.Internal, "apply bulk insert batch: %v", err)
+	}
+
+	var inserted, failed int64
+	for _, r := range results {
+		if r.Success {
+			inserted++
+		} else {
+			failed++
+		}
+	}
+
+	return stream.SendAndClose(&pb.BulkInsertResponse{
+		Results:  results,
+		Inserted: inserted,
+		Failed:   failed,
+	})
+}
+
 // Delete handles vector deletion (write operation)
 // Note: Leadership check is handled by LeaderRedirectInterceptor
 func (s *ClusterServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
@@ -128,13 +439,166 @@ func (s *ClusterServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.
 	}, nil
 }
 
-// Search handles vector search (read operation - can be handled by any node)
+// DeleteBatch handles bulk vector deletion (write operation), proposing
+// all deletes as a single Raft entry, the delete-side counterpart to
+// InsertBatch.
+// Note: Leadership check is handled by LeaderRedirectInterceptor.
+func (s *ClusterServer) DeleteBatch(ctx context.Context, req *pb.DeleteBatchRequest) (*pb.DeleteBatchResponse, error) {
+	if s.onDeleteBatch == nil {
+		return &pb.DeleteBatchResponse{}, nil
+	}
+
+	results, err := s.onDeleteBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteBatchResponse{Results: results}, nil
+}
+
+// readIndexPollInterval is how often waitForApplied checks whether the
+// local FSM has caught up to a ReadIndex-returned index.
+const readIndexPollInterval = 10 * time.Millisecond
+
+// Search handles vector search (read operation - any node may serve it,
+// subject to the requested consistency level).
+//
+// Eventual: served locally with no staleness check (prior behavior).
+// BoundedStaleness: served locally if this node's lag is within MaxLagMs,
+// otherwise redirected to the leader the same way writes are.
+// Linearizable: served locally once a ReadIndex barrier confirms this
+// node's applied index has caught up, using the etcd/raft ReadIndex
+// technique so a follower can serve a linearizable read without a leader
+// round-trip for the search itself.
+// Strong, Leader, and LeaderOnly (an alias for the same behavior): always
+// redirected to the leader, since this node has no way to issue a
+// ReadIndex barrier on someone else's behalf.
 func (s *ClusterServer) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	now := time.Now()
+	staleness := s.lag.StalenessMs(now)
+
+	switch req.Consistency {
+	case "Strong", "Leader", "LeaderOnly":
+		if !s.isLeader() {
+			return nil, status.Errorf(codes.FailedPrecondition, "consistency %q requires the leader; this node is not the leader", req.Consistency)
+		}
+	case "BoundedStaleness":
+		if !s.isLeader() && !s.lag.WithinBound(req.MaxLagMs, now) {
+			// Same leader hint LeaderRedirectInterceptor sends, so the
+			// client's RedirectInterceptor retries the search on the leader.
+			if leaderClusterAddr, err := ConvertRaftToClusterAddr(s.getLeaderAddr()); err == nil {
+				grpc.SetHeader(ctx, map[string][]string{"x-leader-addr": {leaderClusterAddr}, "x-redirect": {"true"}})
+			}
+			return nil, status.Errorf(codes.FailedPrecondition, "node staleness %dms exceeds bound %dms", staleness, req.MaxLagMs)
+		}
+	case "Linearizable":
+		index, err := s.raftNode.ReadIndex(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "read index: %v", err)
+		}
+		if err := s.waitForApplied(ctx, index); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *pb.SearchResponse
+	var err error
 	if s.onSearch != nil {
-		return s.onSearch(ctx, req)
+		resp, err = s.onSearch(ctx, req)
+	} else {
+		resp = &pb.SearchResponse{Results: []*pb.SearchResultItem{}}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp.ServedByLeader = s.isLeader()
+	if req.Consistency != "Strong" && req.Consistency != "Leader" {
+		resp.StalenessMs = staleness
+	}
+	return resp, nil
+}
+
+// waitForApplied blocks until the local FSM has applied through index, or
+// ctx is done. Used by Linearizable search to honor a ReadIndex barrier.
+// Prefers onWaitForIndex (a push-based wait signaled directly off the
+// FSM's apply loop) when the server was wired up with one, falling back
+// to polling LastApplied otherwise.
+func (s *ClusterServer) waitForApplied(ctx context.Context, index uint64) error {
+	if s.raftNode.LastApplied() >= index {
+		return nil
+	}
+
+	if s.onWaitForIndex != nil {
+		if err := s.onWaitForIndex(ctx, index); err != nil {
+			return status.Errorf(codes.DeadlineExceeded, "waiting for local apply index %d: %v", index, err)
+		}
+		return nil
 	}
 
-	return &pb.SearchResponse{Results: []*pb.SearchResultItem{}}, nil
+	ticker := time.NewTicker(readIndexPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if s.raftNode.LastApplied() >= index {
+				return nil
+			}
+		case <-ctx.Done():
+			return status.Errorf(codes.DeadlineExceeded, "timed out waiting for local apply index %d: %v", index, ctx.Err())
+		}
+	}
+}
+
+// Watch streams insert/delete/collection-create events to a subscriber,
+// resuming from req.StartRevision (a prior Watch's last delivered Index)
+// if set. The actual fanout logic lives behind onWatch (see
+// consensus.WatchHub) so this package doesn't need to import consensus.
+func (s *ClusterServer) Watch(req *pb.WatchRequest, stream pb.ClusterService_WatchServer) error {
+	if s.onWatch == nil {
+		return status.Errorf(codes.Unimplemented, "watch is not enabled on this node")
+	}
+	return s.onWatch(stream.Context(), req, stream.Send)
+}
+
+// CreateBackup writes an FSM snapshot to req.Dest on this node, the
+// server-local counterpart to ClusterClient.SnapshotSave (which instead
+// streams the snapshot to the caller over the ExportSnapshot RPC): useful
+// when the operator is already on the node or dest is a mount the node
+// itself can reach (s3:// destinations are rejected for now rather than
+// silently written as a local file under that name).
+func (s *ClusterServer) CreateBackup(ctx context.Context, req *pb.CreateBackupRequest) (*pb.CreateBackupResponse, error) {
+	if s.onCreateBackup == nil {
+		return nil, status.Errorf(codes.Unimplemented, "backup is not enabled on this node")
+	}
+	if err := s.onCreateBackup(ctx, req.Dest); err != nil {
+		return &pb.CreateBackupResponse{Success: false, Message: err.Error()}, err
+	}
+	return &pb.CreateBackupResponse{Success: true, Message: fmt.Sprintf("backup written to %s", req.Dest)}, nil
+}
+
+// RestoreBackup restores the FSM from req.Src (the cluster-equivalent of
+// etcd's "snapshot restore"), an operator maintenance operation normally
+// run against a freshly bootstrapped single-node cluster rather than a live
+// one: it replaces this node's entire vector/payload state in place.
+func (s *ClusterServer) RestoreBackup(ctx context.Context, req *pb.RestoreBackupRequest) (*pb.RestoreBackupResponse, error) {
+	if s.onRestoreBackup == nil {
+		return nil, status.Errorf(codes.Unimplemented, "restore is not enabled on this node")
+	}
+	if err := s.onRestoreBackup(ctx, req.Src); err != nil {
+		return &pb.RestoreBackupResponse{Success: false, Message: err.Error()}, err
+	}
+	return &pb.RestoreBackupResponse{Success: true, Message: fmt.Sprintf("restored from %s", req.Src)}, nil
+}
+
+// ReadIndex exposes this node's Raft read-index barrier over gRPC, so a
+// follower serving a Linearizable search can forward its ReadIndex query to
+// whichever node is currently leader.
+func (s *ClusterServer) ReadIndex(ctx context.Context, req *pb.ReadIndexRequest) (*pb.ReadIndexResponse, error) {
+	index, err := s.raftNode.ReadIndex(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "read index: %v", err)
+	}
+	return &pb.ReadIndexResponse{Index: index}, nil
 }
 
 // GetClusterInfo returns information about the cluster
@@ -147,6 +611,7 @@ func (s *ClusterServer) GetClusterInfo(ctx context.Context, req *pb.GetClusterIn
 
 	config := configFuture.Configuration()
 	leaderAddr := s.getLeaderAddr()
+	leaderLastIndex := s.raftNode.LastIndex()
 
 	// Build node list
 	nodes := make([]*pb.NodeInfo, 0, len(config.Servers))
@@ -157,21 +622,58 @@ func (s *ClusterServer) GetClusterInfo(ctx context.Context, req *pb.GetClusterIn
 		}
 
 		nodes = append(nodes, &pb.NodeInfo{
-			NodeId:  string(server.ID),
-			Addr:    string(server.Address),
-			State:   nodeState,
-			IsVoter: server.Suffrage == raft.Voter,
+			NodeId:   string(server.ID),
+			Addr:     string(server.Address),
+			State:    nodeState,
+			IsVoter:  server.Suffrage == raft.Voter,
+			Suffrage: s.suffrageLabel(ctx, server, leaderLastIndex),
 		})
 	}
 
+	var minCapabilities *pb.ClusterCapabilities
+	if s.onGetMinClusterCapability != nil {
+		if caps, err := s.onGetMinClusterCapability(ctx); err != nil {
+			log.Printf("Failed to get min cluster capability: %v", err)
+		} else {
+			minCapabilities = caps
+		}
+	}
+
 	return &pb.ClusterInfoResponse{
-		LeaderId:   s.findLeaderID(config.Servers, leaderAddr),
-		LeaderAddr: leaderAddr,
-		Nodes:      nodes,
-		State:      s.raftNode.State().String(),
+		LeaderId:        s.findLeaderID(config.Servers, leaderAddr),
+		LeaderAddr:      leaderAddr,
+		Nodes:           nodes,
+		State:           s.raftNode.State().String(),
+		MinCapabilities: minCapabilities,
 	}, nil
 }
 
+// suffrageLabel classifies server as "Voter", "Nonvoter", or
+// "Learner-Catching-Up" for GetClusterInfo. A Nonvoter is only reported as
+// caught up once its self-reported applied index is within
+// learnerLagThreshold of leaderLastIndex; probeNodeStatus being unset, or
+// the probe failing (e.g. the learner is unreachable), falls back to
+// "Nonvoter" rather than blocking the whole response on one bad peer.
+func (s *ClusterServer) suffrageLabel(ctx context.Context, server raft.Server, leaderLastIndex uint64) string {
+	if server.Suffrage == raft.Voter {
+		return "Voter"
+	}
+	if s.probeNodeStatus == nil {
+		return "Nonvoter"
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	status, err := s.probeNodeStatus(probeCtx, string(server.Address))
+	if err != nil {
+		return "Nonvoter"
+	}
+	if leaderLastIndex > status.AppliedIndex && leaderLastIndex-status.AppliedIndex > s.learnerLagThreshold {
+		return "Learner-Catching-Up"
+	}
+	return "Nonvoter"
+}
+
 // findLeaderID finds the leader ID from server list
 func (s *ClusterServer) findLeaderID(servers []raft.Server, leaderAddr string) string {
 	for _, server := range servers {
@@ -182,18 +684,79 @@ func (s *ClusterServer) findLeaderID(servers []raft.Server, leaderAddr string) s
 	return ""
 }
 
-// JoinCluster handles node join requests
+// JoinCluster handles node join requests. A node joining with
+// req.AsLearner true is added as a non-voting learner via AddNonvoter
+// instead of a full voter, so it can catch up on a large HNSW+SQLite
+// state without being able to affect quorum while it's still behind; see
+// PromoteCluster for moving it to a voter once it's caught up.
 // Note: Leadership check is handled by LeaderRedirectInterceptor
 func (s *ClusterServer) JoinCluster(ctx context.Context, req *pb.JoinClusterRequest) (*pb.JoinClusterResponse, error) {
-	log.Printf("Adding node %s at %s to cluster", req.NodeId, req.NodeAddr)
+	log.Printf("Adding node %s at %s to cluster (learner=%v)", req.NodeId, req.NodeAddr, req.AsLearner)
+
+	// Reject a newcomer whose advertised ProtocolVersion falls outside the
+	// window this leader supports, before touching Raft configuration at
+	// all. A zero window (both bounds unset) means the server wasn't
+	// wired up with capability negotiation, so every version is accepted.
+	if s.minSupportedProtocolVersion != 0 || s.maxSupportedProtocolVersion != 0 {
+		if req.ProtocolVersion < s.minSupportedProtocolVersion || req.ProtocolVersion > s.maxSupportedProtocolVersion {
+			msg := fmt.Sprintf("protocol version %d outside supported window [%d, %d]", req.ProtocolVersion, s.minSupportedProtocolVersion, s.maxSupportedProtocolVersion)
+			return &pb.JoinClusterResponse{Success: false, Message: msg}, status.Errorf(codes.FailedPrecondition, "%s", msg)
+		}
+	}
 
-	// Add voter to Raft cluster
-	future := s.raftNode.AddVoter(
-		raft.ServerID(req.NodeId),
-		raft.ServerAddress(req.NodeAddr),
-		0,
-		10*time.Second,
-	)
+	// Idempotent rejoin: if req.NodeId is already a voter, treat this as
+	// success instead of re-proposing an AddVoter that raft would reject
+	// (or silently no-op) anyway. Lets a node that crashed and restarted
+	// retry JoinCluster without special-casing "already a member". A
+	// learner rejoin request still proceeds, since AddNonvoter on an
+	// existing server is itself idempotent.
+	if !req.AsLearner {
+		if configFuture := s.raftNode.GetConfiguration(); configFuture.Error() == nil {
+			for _, existing := range configFuture.Configuration().Servers {
+				if string(existing.ID) == req.NodeId && existing.Suffrage == raft.Voter {
+					log.Printf("Node %s is already a voter, treating join as successful", req.NodeId)
+					return &pb.JoinClusterResponse{
+						Success:  true,
+						Message:  "already a voter",
+						LeaderId: s.nodeID,
+					}, nil
+				}
+			}
+		}
+	}
+
+	// Probe the prospective member before touching Raft configuration at
+	// all: AddNonvoter/AddVoter succeeding just means the leader proposed
+	// the change, not that the new peer is actually reachable, and an
+	// unreachable learner sits there stalling replication (and any
+	// promote attempt) until an operator notices and removes it by hand.
+	if s.onHealthCheckNode != nil {
+		healthCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := s.onHealthCheckNode(healthCtx, req.NodeAddr)
+		cancel()
+		if err != nil {
+			msg := fmt.Sprintf("health check failed for prospective member %s at %s: %v", req.NodeId, req.NodeAddr, err)
+			log.Print(msg)
+			return &pb.JoinClusterResponse{Success: false, Message: msg}, status.Errorf(codes.Unavailable, "%s", msg)
+		}
+	}
+
+	var future raft.IndexFuture
+	if req.AsLearner {
+		future = s.raftNode.AddNonvoter(
+			raft.ServerID(req.NodeId),
+			raft.ServerAddress(req.NodeAddr),
+			0,
+			10*time.Second,
+		)
+	} else {
+		future = s.raftNode.AddVoter(
+			raft.ServerID(req.NodeId),
+			raft.ServerAddress(req.NodeAddr),
+			0,
+			10*time.Second,
+		)
+	}
 
 	if err := future.Error(); err != nil {
 		log.Printf("Failed to add node: %v", err)
@@ -205,13 +768,216 @@ func (s *ClusterServer) JoinCluster(ctx context.Context, req *pb.JoinClusterRequ
 
 	log.Printf("Successfully added node %s", req.NodeId)
 
+	if s.onRegisterCapabilities != nil {
+		if err := s.onRegisterCapabilities(ctx, req.NodeId, req.ProtocolVersion, req.Features); err != nil {
+			// The node is already a cluster member at this point; failing
+			// the whole join over a capability-gossip hiccup would leave
+			// it stuck neither in nor out, so this is logged, not fatal.
+			log.Printf("Failed to register capabilities for node %s: %v", req.NodeId, err)
+		}
+	}
+
+	message := "joined cluster successfully"
+	if req.AsLearner {
+		message = "joined cluster as a learner; use 'promote' once it's caught up"
+	}
 	return &pb.JoinClusterResponse{
 		Success:  true,
-		Message:  "joined cluster successfully",
+		Message:  message,
 		LeaderId: s.nodeID,
 	}, nil
 }
 
+// NodeStatus reports this node's own Raft progress, so a caller on another
+// node (GetClusterInfo classifying a learner, or the promote CLI checking
+// whether a learner is caught up) can read it without reaching into Raft
+// internals directly.
+func (s *ClusterServer) NodeStatus(ctx context.Context, req *pb.NodeStatusRequest) (*pb.NodeStatusResponse, error) {
+	return &pb.NodeStatusResponse{
+		NodeId:       s.nodeID,
+		State:        s.raftNode.State().String(),
+		AppliedIndex: s.raftNode.LastApplied(),
+		LastIndex:    s.raftNode.LastIndex(),
+	}, nil
+}
+
+// PromoteCluster transitions a learner to a full voter, but only once
+// req.AppliedIndex (the learner's own applied index, as reported by its
+// NodeStatus) is within req.MaxLagIndex of this node's LastIndex. Promoting
+// a learner that's still far behind would let it affect quorum before it
+// can actually keep up with writes.
+// Note: Leadership check is handled by LeaderRedirectInterceptor
+func (s *ClusterServer) PromoteCluster(ctx context.Context, req *pb.PromoteClusterRequest) (*pb.PromoteClusterResponse, error) {
+	leaderLastIndex := s.raftNode.LastIndex()
+	if leaderLastIndex > req.AppliedIndex && leaderLastIndex-req.AppliedIndex > req.MaxLagIndex {
+		return &pb.PromoteClusterResponse{
+			Success: false,
+			Message: fmt.Sprintf("node %s is %d entries behind, exceeding max lag %d", req.NodeId, leaderLastIndex-req.AppliedIndex, req.MaxLagIndex),
+		}, status.Errorf(codes.FailedPrecondition, "learner too far behind to promote")
+	}
+
+	future := s.raftNode.AddVoter(
+		raft.ServerID(req.NodeId),
+		raft.ServerAddress(req.NodeAddr),
+		0,
+		10*time.Second,
+	)
+	if err := future.Error(); err != nil {
+		log.Printf("Failed to promote node %s: %v", req.NodeId, err)
+		return &pb.PromoteClusterResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to promote node: %v", err),
+		}, status.Errorf(codes.Internal, "failed to promote node: %v", err)
+	}
+
+	log.Printf("Successfully promoted node %s to voter", req.NodeId)
+	return &pb.PromoteClusterResponse{
+		Success: true,
+		Message: "promoted to voter",
+	}, nil
+}
+
+// DemoteCluster shrinks the cluster safely by first demoting req.NodeId
+// from voter to non-voter (DemoteVoter), then removing it entirely
+// (RemoveServer), rather than removing a voter directly. Removing a voter
+// outright shrinks the quorum size at the same moment the server
+// disappears, which can cost availability if another server is also
+// unreachable; demoting first means the server leaves quorum accounting
+// before it leaves the configuration.
+// Note: Leadership check is handled by LeaderRedirectInterceptor
+func (s *ClusterServer) DemoteCluster(ctx context.Context, req *pb.DemoteClusterRequest) (*pb.DemoteClusterResponse, error) {
+	if err := s.raftNode.DemoteVoter(raft.ServerID(req.NodeId), 0, 10*time.Second).Error(); err != nil {
+		log.Printf("Failed to demote node %s: %v", req.NodeId, err)
+		return &pb.DemoteClusterResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to demote node: %v", err),
+		}, status.Errorf(codes.Internal, "failed to demote node: %v", err)
+	}
+
+	if err := s.raftNode.RemoveServer(raft.ServerID(req.NodeId), 0, 10*time.Second).Error(); err != nil {
+		log.Printf("Failed to remove demoted node %s: %v", req.NodeId, err)
+		return &pb.DemoteClusterResponse{
+			Success: false,
+			Message: fmt.Sprintf("demoted but failed to remove node: %v", err),
+		}, status.Errorf(codes.Internal, "demoted but failed to remove node: %v", err)
+	}
+
+	log.Printf("Successfully demoted and removed node %s", req.NodeId)
+	return &pb.DemoteClusterResponse{
+		Success: true,
+		Message: "demoted and removed from cluster",
+	}, nil
+}
+
+// raftAddrForNode returns nodeID's Raft address from the current
+// configuration, for a caller (TransferLeadership, pickTransferTarget)
+// that only has a node ID to work with.
+func (s *ClusterServer) raftAddrForNode(nodeID string) (string, error) {
+	configFuture := s.raftNode.GetConfiguration()
+	if err := configFuture.Error(); err != nil {
+		return "", fmt.Errorf("get configuration: %w", err)
+	}
+	for _, srv := range configFuture.Configuration().Servers {
+		if string(srv.ID) == nodeID {
+			return string(srv.Address), nil
+		}
+	}
+	return "", fmt.Errorf("node %s not found in cluster configuration", nodeID)
+}
+
+// pickTransferTarget returns the ID and address of a voter, other than
+// this node, that Drain can hand leadership to before this node leaves
+// the cluster. Prefers nothing in particular among the remaining voters —
+// any of them can be given the leadership candidacy hint hashicorp/raft
+// needs — since Raft itself still runs a normal election to confirm it.
+func (s *ClusterServer) pickTransferTarget() (raft.ServerID, raft.ServerAddress, error) {
+	configFuture := s.raftNode.GetConfiguration()
+	if err := configFuture.Error(); err != nil {
+		return "", "", fmt.Errorf("get configuration: %w", err)
+	}
+	for _, srv := range configFuture.Configuration().Servers {
+		if srv.Suffrage == raft.Voter && string(srv.ID) != s.nodeID {
+			return srv.ID, srv.Address, nil
+		}
+	}
+	return "", "", fmt.Errorf("no other voter available to transfer leadership to")
+}
+
+// TransferLeadership hands the leader role to req.NodeId, e.g. before a
+// planned restart of the current leader, without removing it from the
+// cluster (see Drain for the full node-eviction sequence). req.NodeAddr
+// is used if set, otherwise it's resolved from the current configuration.
+// Note: Leadership check is handled by LeaderRedirectInterceptor.
+func (s *ClusterServer) TransferLeadership(ctx context.Context, req *pb.TransferLeadershipRequest) (*pb.TransferLeadershipResponse, error) {
+	addr := req.NodeAddr
+	if addr == "" {
+		resolved, err := s.raftAddrForNode(req.NodeId)
+		if err != nil {
+			return &pb.TransferLeadershipResponse{Success: false, Message: err.Error()}, status.Errorf(codes.NotFound, "%s", err)
+		}
+		addr = resolved
+	}
+
+	if err := s.raftNode.LeadershipTransfer(raft.ServerID(req.NodeId), raft.ServerAddress(addr)).Error(); err != nil {
+		log.Printf("Failed to transfer leadership to %s: %v", req.NodeId, err)
+		return &pb.TransferLeadershipResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to transfer leadership: %v", err),
+		}, status.Errorf(codes.Internal, "failed to transfer leadership: %v", err)
+	}
+
+	log.Printf("Successfully transferred leadership to %s", req.NodeId)
+	return &pb.TransferLeadershipResponse{
+		Success: true,
+		Message: fmt.Sprintf("leadership transferred to %s", req.NodeId),
+	}, nil
+}
+
+// Drain prepares this node to leave the cluster safely, for a rolling
+// restart or a Kubernetes pod eviction: if this node currently holds
+// leadership, it's handed off to another voter first (via
+// LeadershipTransfer) so the cluster doesn't have to wait out an election
+// timeout to notice this node is going away, and every write this node
+// would otherwise have accepted is instead rejected with FailedPrecondition
+// plus a leader hint (the ordinary LeaderRedirectInterceptor behavior for
+// any node that isn't leader) the moment the transfer completes. Once no
+// longer leader, this node asks the new leader to remove it from the Raft
+// configuration via onLeaveClusterSelf, the self-removal counterpart to an
+// operator-initiated LeaveCluster.
+func (s *ClusterServer) Drain(ctx context.Context, req *pb.DrainRequest) (*pb.DrainResponse, error) {
+	if s.isLeader() {
+		targetID, targetAddr, err := s.pickTransferTarget()
+		if err != nil {
+			return &pb.DrainResponse{Success: false, Message: err.Error()}, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		if err := s.raftNode.LeadershipTransfer(targetID, targetAddr).Error(); err != nil {
+			log.Printf("Drain: failed to transfer leadership to %s: %v", targetID, err)
+			return &pb.DrainResponse{
+				Success: false,
+				Message: fmt.Sprintf("failed to transfer leadership: %v", err),
+			}, status.Errorf(codes.Internal, "failed to transfer leadership: %v", err)
+		}
+		log.Printf("Drain: transferred leadership to %s", targetID)
+	}
+
+	if s.onLeaveClusterSelf == nil {
+		return &pb.DrainResponse{
+			Success: true,
+			Message: "drained; operator must still remove this node from the cluster",
+		}, nil
+	}
+
+	if err := s.onLeaveClusterSelf(ctx); err != nil {
+		log.Printf("Drain: transferred leadership but failed to leave cluster: %v", err)
+		return &pb.DrainResponse{
+			Success: false,
+			Message: fmt.Sprintf("drained but failed to leave cluster: %v", err),
+		}, status.Errorf(codes.Internal, "drained but failed to leave cluster: %v", err)
+	}
+
+	return &pb.DrainResponse{Success: true, Message: "drained and removed from cluster"}, nil
+}
+
 // LeaveCluster handles node removal requests
 // Note: Leadership check is handled by LeaderRedirectInterceptor
 func (s *ClusterServer) LeaveCluster(ctx context.Context, req *pb.LeaveClusterRequest) (*pb.LeaveClusterResponse, error) {
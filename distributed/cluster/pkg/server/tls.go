@@ -0,0 +1,78 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// LoadServerTLSCredentials builds gRPC server transport credentials from a
+// cert/key pair. If caFile is set, client certificates are required and
+// verified against it (mTLS) before a connection is even accepted,
+// letting AuthInterceptor authenticate the caller by its peer
+// certificate's CommonName; otherwise the server presents TLS but accepts
+// any client, and auth (if enabled) relies on bearer tokens alone.
+func LoadServerTLSCredentials(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load server keypair: %w", err)
+	}
+
+	tlsCfg := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file %s: %w", caFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// LoadPeerDialCredentials builds the transport credentials a node uses to
+// dial another node directly (ReadForwarder's leader round-trip,
+// probeNodeStatus's learner probe), as opposed to a ClusterClient talking
+// to the cluster from outside it. Plaintext if no TLS files are
+// configured, server-TLS if only caFile is set, or mTLS if certFile/
+// keyFile are also set, matching the options LoadServerTLSCredentials
+// offers for the inbound side of the same connection.
+func LoadPeerDialCredentials(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	if certFile == "" && keyFile == "" && caFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsCfg := &tls.Config{}
+
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file %s: %w", caFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load peer dial keypair: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsCfg), nil
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	types "github.com/uttom-akash/vector-xlite/standalone/clients/go/types"
+)
+
+const defaultMaxInFlight = 20
+
+// InsertBatch inserts many points concurrently, bounded by
+// opts.MaxInFlight in-flight gRPC calls at a time. Results are reported
+// per original slice index in the returned BatchResult rather than failing
+// the whole call, so the caller can retry just the failures.
+func (c *Client) InsertBatch(ctx context.Context, points []*types.InsertPoint, opts types.BatchOptions) (*types.BatchResult, error) {
+	maxInFlight := opts.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = defaultMaxInFlight
+	}
+
+	gate := make(chan struct{}, maxInFlight)
+	result := &types.BatchResult{Errors: make(map[int]error)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var stopped int32
+
+	for i, p := range points {
+		if opts.StopOnError && atomic.LoadInt32(&stopped) == 1 {
+			break
+		}
+
+		wg.Add(1)
+		gate <- struct{}{}
+		go func(index int, pt *types.InsertPoint) {
+			defer wg.Done()
+			defer func() { <-gate }()
+
+			if err := c.insertWithRetry(ctx, pt, opts); err != nil {
+				mu.Lock()
+				result.Errors[index] = err
+				mu.Unlock()
+				if opts.StopOnError {
+					atomic.StoreInt32(&stopped, 1)
+				}
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// SearchBatch runs many searches concurrently, bounded by opts.MaxInFlight
+// in-flight gRPC calls at a time. Each result carries its original index so
+// callers can match responses back to their queries regardless of
+// completion order.
+func (c *Client) SearchBatch(ctx context.Context, queries []*types.SearchPoint, opts types.BatchOptions) ([]types.SearchBatchItem, error) {
+	maxInFlight := opts.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = defaultMaxInFlight
+	}
+
+	gate := make(chan struct{}, maxInFlight)
+	results := make([]types.SearchBatchItem, len(queries))
+	var wg sync.WaitGroup
+	var stopped int32
+
+	for i, q := range queries {
+		if opts.StopOnError && atomic.LoadInt32(&stopped) == 1 {
+			break
+		}
+
+		wg.Add(1)
+		gate <- struct{}{}
+		go func(index int, query *types.SearchPoint) {
+			defer wg.Done()
+			defer func() { <-gate }()
+
+			resp, err := c.searchWithRetry(ctx, query, opts)
+			results[index] = types.SearchBatchItem{Index: index, Response: resp, Err: err}
+			if err != nil && opts.StopOnError {
+				atomic.StoreInt32(&stopped, 1)
+			}
+		}(i, q)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// insertWithRetry runs a single Insert, retrying up to
+// opts.RetryPolicy.MaxAttempts times with a fixed backoff, and applying
+// opts.PerRequestTimeout to each attempt.
+func (c *Client) insertWithRetry(ctx context.Context, p *types.InsertPoint, opts types.BatchOptions) error {
+	attempts, backoff := retryParams(opts)
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		err = func() error {
+			attemptCtx, cancel := perRequestContext(ctx, opts)
+			defer cancel()
+			return c.Insert(attemptCtx, p)
+		}()
+		if err == nil {
+			return nil
+		}
+		if attempt < attempts-1 && backoff > 0 {
+			time.Sleep(backoff)
+		}
+	}
+	return err
+}
+
+// searchWithRetry is the SearchBatch counterpart of insertWithRetry.
+func (c *Client) searchWithRetry(ctx context.Context, q *types.SearchPoint, opts types.BatchOptions) (*types.SearchResponse, error) {
+	attempts, backoff := retryParams(opts)
+	var resp *types.SearchResponse
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		resp, err = func() (*types.SearchResponse, error) {
+			attemptCtx, cancel := perRequestContext(ctx, opts)
+			defer cancel()
+			return c.Search(attemptCtx, q)
+		}()
+		if err == nil {
+			return resp, nil
+		}
+		if attempt < attempts-1 && backoff > 0 {
+			time.Sleep(backoff)
+		}
+	}
+	return nil, err
+}
+
+// retryParams extracts attempt count and backoff from opts.RetryPolicy,
+// defaulting to a single attempt with no backoff when unset.
+func retryParams(opts types.BatchOptions) (attempts int, backoff time.Duration) {
+	if opts.RetryPolicy == nil || opts.RetryPolicy.MaxAttempts <= 0 {
+		return 1, 0
+	}
+	return opts.RetryPolicy.MaxAttempts, opts.RetryPolicy.Backoff
+}
+
+// perRequestContext wraps ctx in a timeout when opts.PerRequestTimeout is
+// set; otherwise it returns ctx with a no-op cancel.
+func perRequestContext(ctx context.Context, opts types.BatchOptions) (context.Context, context.CancelFunc) {
+	if opts.PerRequestTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, opts.PerRequestTimeout)
+}
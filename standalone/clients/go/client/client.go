@@ -3,33 +3,16 @@ package client
 import (
 	"context"
 	"errors"
-	"fmt"
-	"time"
 
 	pb "github.com/uttom-akash/vector-xlite/standalone/clients/go/pb"
 	types "github.com/uttom-akash/vector-xlite/standalone/clients/go/types"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Client struct {
-	conn     *grpc.ClientConn
-	pbClient pb.VectorXLitePBClient
-}
-
-// NewClient dials the server address and returns a Client.
-// Uses insecure transport for local testing; update for production.
-func NewClient(ctx context.Context, addr string, dialTimeout time.Duration) (*Client, error) {
-	ctxDial, cancel := context.WithTimeout(ctx, dialTimeout)
-	defer cancel()
-	conn, err := grpc.DialContext(ctxDial, addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
-	if err != nil {
-		return nil, fmt.Errorf("dial grpc: %w", err)
-	}
-	return &Client{
-		conn:     conn,
-		pbClient: pb.NewVectorXLitePBClient(conn),
-	}, nil
+	conn        *grpc.ClientConn
+	pbClient    pb.VectorXLitePBClient
+	retryPolicy *RetryPolicy
 }
 
 // Close closes the underlying grpc connection.
@@ -40,7 +23,9 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
-// CreateCollection sends a CreateCollection request to the server.
+// CreateCollection sends a CreateCollection request to the server. Retried
+// per the client's RetryPolicy: creating the same collection twice is
+// idempotent, so this is always eligible for retry.
 func (c *Client) CreateCollection(ctx context.Context, cfg *types.CollectionConfig) error {
 	if cfg == nil {
 		return errors.New("nil config")
@@ -51,12 +36,16 @@ func (c *Client) CreateCollection(ctx context.Context, cfg *types.CollectionConf
 		VectorDimension:    cfg.VectorDimension,
 		PayloadTableSchema: cfg.PayloadTableSchema,
 		IndexFilePath:      cfg.IndexFilePath,
+		VectorEncoding:     cfg.VectorEncoding.String(),
 	}
-	_, err := c.pbClient.CreateCollection(ctx, pbCfg)
-	return err
+	return retryErr(ctx, c, false, func() error {
+		_, err := c.pbClient.CreateCollection(ctx, pbCfg)
+		return err
+	})
 }
 
-// Insert sends an InsertPoint request to the server.
+// Insert sends an InsertPoint request to the server. Not idempotent, so
+// retry only happens when the client's RetryPolicy sets AllowUnsafeRetry.
 func (c *Client) Insert(ctx context.Context, p *types.InsertPoint) error {
 	if p == nil {
 		return errors.New("nil point")
@@ -67,11 +56,14 @@ func (c *Client) Insert(ctx context.Context, p *types.InsertPoint) error {
 		Vector:             p.Vector,
 		PayloadInsertQuery: p.PayloadInsertQuery,
 	}
-	_, err := c.pbClient.Insert(ctx, pbPt)
-	return err
+	return retryErr(ctx, c, true, func() error {
+		_, err := c.pbClient.Insert(ctx, pbPt)
+		return err
+	})
 }
 
-// Delete sends a Delete request to the server.
+// Delete sends a Delete request to the server. Not idempotent, so retry
+// only happens when the client's RetryPolicy sets AllowUnsafeRetry.
 func (c *Client) Delete(ctx context.Context, collectionName string, id int64) (*pb.DeleteResponsePB, error) {
 	if collectionName == "" {
 		return nil, errors.New("collection name cannot be empty")
@@ -80,10 +72,14 @@ func (c *Client) Delete(ctx context.Context, collectionName string, id int64) (*
 		CollectionName: collectionName,
 		Id:             id,
 	}
-	return c.pbClient.Delete(ctx, pbReq)
+	return retryCall(ctx, c, true, func() (*pb.DeleteResponsePB, error) {
+		return c.pbClient.Delete(ctx, pbReq)
+	})
 }
 
-// DeleteCollection sends a DeleteCollection request to the server.
+// DeleteCollection sends a DeleteCollection request to the server. Not
+// idempotent, so retry only happens when the client's RetryPolicy sets
+// AllowUnsafeRetry.
 func (c *Client) DeleteCollection(ctx context.Context, collectionName string) (*pb.DeleteResponsePB, error) {
 	if collectionName == "" {
 		return nil, errors.New("collection name cannot be empty")
@@ -91,11 +87,13 @@ func (c *Client) DeleteCollection(ctx context.Context, collectionName string) (*
 	pbReq := &pb.DeleteCollectionRequestPB{
 		CollectionName: collectionName,
 	}
-	return c.pbClient.DeleteCollection(ctx, pbReq)
+	return retryCall(ctx, c, true, func() (*pb.DeleteResponsePB, error) {
+		return c.pbClient.DeleteCollection(ctx, pbReq)
+	})
 }
 
-
-// Search sends a SearchPoint request and converts the response.
+// Search sends a SearchPoint request and converts the response. Reads are
+// idempotent, so this is always eligible for retry.
 func (c *Client) Search(ctx context.Context, q *types.SearchPoint) (*types.SearchResponse, error) {
 	if q == nil {
 		return nil, errors.New("nil search point")
@@ -105,12 +103,25 @@ func (c *Client) Search(ctx context.Context, q *types.SearchPoint) (*types.Searc
 		Vector:             q.Vector,
 		TopK:               q.TopK,
 		PayloadSearchQuery: q.PayloadSearchQuery,
-	}
-	pbResp, err := c.pbClient.Search(ctx, pbReq)
+		Consistency:        q.Consistency.Level.String(),
+		MaxLagMs:           q.Consistency.MaxLagMs,
+		FilterExpression:   q.Filter,
+		SearchStrategy:     q.Strategy.String(),
+		FilterMultiplier:   q.FilterMultiplier,
+	}
+	pbResp, err := retryCall(ctx, c, false, func() (*pb.SearchResponsePB, error) {
+		return c.pbClient.Search(ctx, pbReq)
+	})
 	if err != nil {
 		return nil, err
 	}
-	resp := &types.SearchResponse{Results: make([]types.SearchResultItem, 0, len(pbResp.Results))}
+	resp := &types.SearchResponse{
+		Results:            make([]types.SearchResultItem, 0, len(pbResp.Results)),
+		ServedByLeader:     pbResp.ServedByLeader,
+		StalenessMs:        pbResp.StalenessMs,
+		CandidatesExamined: pbResp.CandidatesExamined,
+		FilterMatched:      pbResp.FilterMatched,
+	}
 	for _, r := range pbResp.Results {
 		item := types.SearchResultItem{
 			Rowid:    r.Rowid,
@@ -126,6 +137,7 @@ func (c *Client) Search(ctx context.Context, q *types.SearchPoint) (*types.Searc
 }
 
 // CollectionExists checks if a collection with the given name exists.
+// Reads are idempotent, so this is always eligible for retry.
 func (c *Client) CollectionExists(ctx context.Context, collectionName string) (bool, error) {
 	if collectionName == "" {
 		return false, errors.New("collection name cannot be empty")
@@ -133,7 +145,9 @@ func (c *Client) CollectionExists(ctx context.Context, collectionName string) (b
 	pbReq := &pb.CollectionExistsRequestPB{
 		CollectionName: collectionName,
 	}
-	pbResp, err := c.pbClient.CollectionExists(ctx, pbReq)
+	pbResp, err := retryCall(ctx, c, false, func() (*pb.CollectionExistsResponsePB, error) {
+		return c.pbClient.CollectionExists(ctx, pbReq)
+	})
 	if err != nil {
 		return false, err
 	}
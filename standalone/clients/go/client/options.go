@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	pb "github.com/uttom-akash/vector-xlite/standalone/clients/go/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// ClientOptions configures transport security, auth, and dial behavior for
+// NewClientWithOptions. There is no implicit insecure fallback: Insecure
+// must be set explicitly, so a production deployment can't silently ship
+// without transport security just because nobody configured TLS.
+type ClientOptions struct {
+	// Insecure dials without transport security. Must be set explicitly.
+	Insecure bool
+
+	// TLSConfig, when set, is used directly (its Certificates field
+	// enables mutual TLS). Takes precedence over CACertFile.
+	TLSConfig *tls.Config
+	// ServerName overrides SNI / server name verification.
+	ServerName string
+	// CACertFile is a convenience loader: if TLSConfig is nil and this is
+	// set, a TLSConfig trusting this PEM file is built automatically.
+	CACertFile string
+
+	// PerRPCToken, if set, is attached to every RPC as an
+	// "authorization: Bearer <token>" metadata header.
+	PerRPCToken string
+
+	UnaryInterceptors  []grpc.UnaryClientInterceptor
+	StreamInterceptors []grpc.StreamClientInterceptor
+
+	// Keepalive, if set, is passed to grpc.WithKeepaliveParams.
+	Keepalive *keepalive.ClientParameters
+
+	// DialTimeout bounds how long dialing may block (default: 10s).
+	DialTimeout time.Duration
+
+	// RetryPolicy, if set, is applied to every Client method call.
+	RetryPolicy *RetryPolicy
+}
+
+// NewClient dials addr without transport security, for local testing.
+// For anything beyond loopback, use NewClientWithOptions with TLSConfig or
+// CACertFile set.
+func NewClient(ctx context.Context, addr string, dialTimeout time.Duration) (*Client, error) {
+	return NewClientWithOptions(ctx, addr, ClientOptions{
+		Insecure:    true,
+		DialTimeout: dialTimeout,
+	})
+}
+
+// NewClientWithOptions dials addr using the transport security, auth, and
+// dial settings in opts.
+func NewClientWithOptions(ctx context.Context, addr string, opts ClientOptions) (*Client, error) {
+	transportCreds, err := opts.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
+		grpc.WithBlock(),
+	}
+	if opts.PerRPCToken != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken(opts.PerRPCToken)))
+	}
+	if len(opts.UnaryInterceptors) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(opts.UnaryInterceptors...))
+	}
+	if len(opts.StreamInterceptors) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainStreamInterceptor(opts.StreamInterceptors...))
+	}
+	if opts.Keepalive != nil {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(*opts.Keepalive))
+	}
+
+	dialTimeout := opts.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = 10 * time.Second
+	}
+	ctxDial, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctxDial, addr, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("dial grpc: %w", err)
+	}
+	return &Client{
+		conn:        conn,
+		pbClient:    pb.NewVectorXLitePBClient(conn),
+		retryPolicy: opts.RetryPolicy,
+	}, nil
+}
+
+// transportCredentials resolves TLSConfig/CACertFile/Insecure into the
+// credentials.TransportCredentials to dial with, in that priority order.
+func (o ClientOptions) transportCredentials() (credentials.TransportCredentials, error) {
+	if o.TLSConfig != nil {
+		cfg := o.TLSConfig.Clone()
+		if o.ServerName != "" {
+			cfg.ServerName = o.ServerName
+		}
+		return credentials.NewTLS(cfg), nil
+	}
+	if o.CACertFile != "" {
+		pool := x509.NewCertPool()
+		pem, err := os.ReadFile(o.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("read ca cert: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", o.CACertFile)
+		}
+		return credentials.NewTLS(&tls.Config{RootCAs: pool, ServerName: o.ServerName}), nil
+	}
+	if o.Insecure {
+		return insecure.NewCredentials(), nil
+	}
+	return nil, errors.New("client: no transport security configured; set TLSConfig, CACertFile, or Insecure: true")
+}
+
+// bearerToken implements credentials.PerRPCCredentials, attaching a static
+// bearer token to every RPC. It requires transport security so the token
+// is never sent in the clear.
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t bearerToken) RequireTransportSecurity() bool {
+	return true
+}
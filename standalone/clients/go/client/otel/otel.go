@@ -0,0 +1,198 @@
+// Package otel wraps the standalone Go client with OpenTelemetry tracing
+// and metrics, for RAG-style pipelines where p50/p99 latency and
+// per-collection error rate need to be observable out of the box.
+package otel
+
+import (
+	"context"
+	"time"
+
+	client "github.com/uttom-akash/vector-xlite/standalone/clients/go/client"
+	types "github.com/uttom-akash/vector-xlite/standalone/clients/go/types"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+const instrumentationName = "github.com/uttom-akash/vector-xlite/standalone/clients/go/client/otel"
+
+// Client wraps a *client.Client via embedding, so every method not
+// overridden below (Close, DeleteCollection, snapshot ops, ...) passes
+// straight through unchanged, and existing call sites that take a
+// *client.Client-shaped value keep working without modification.
+type Client struct {
+	*client.Client
+
+	tracer          trace.Tracer
+	requestDuration metric.Float64Histogram
+	requestTotal    metric.Int64Counter
+}
+
+// NewInstrumentedClient wraps inner so each Insert, Search,
+// CreateCollection and CollectionExists call produces a
+// "vectorxlite.<Method>" span and records the
+// vectorxlite_client_request_duration_seconds histogram and
+// vectorxlite_client_request_total counter, both labeled by rpc and
+// status code.
+func NewInstrumentedClient(inner *client.Client, tp trace.TracerProvider, mp metric.MeterProvider) *Client {
+	duration, total := mustInstruments(mp)
+	return &Client{
+		Client:          inner,
+		tracer:          tp.Tracer(instrumentationName),
+		requestDuration: duration,
+		requestTotal:    total,
+	}
+}
+
+func mustInstruments(mp metric.MeterProvider) (metric.Float64Histogram, metric.Int64Counter) {
+	meter := mp.Meter(instrumentationName)
+	duration, err := meter.Float64Histogram(
+		"vectorxlite_client_request_duration_seconds",
+		metric.WithDescription("Duration of vector-xlite client RPCs"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(err)
+	}
+	total, err := meter.Int64Counter(
+		"vectorxlite_client_request_total",
+		metric.WithDescription("Count of vector-xlite client RPCs"),
+	)
+	if err != nil {
+		panic(err)
+	}
+	return duration, total
+}
+
+// instrument starts a span named "vectorxlite.<name>", runs fn, records
+// the duration/count metrics labeled by rpc and status code, and sets the
+// span's error status on failure.
+func (c *Client) instrument(ctx context.Context, name string, attrs []attribute.KeyValue, fn func(ctx context.Context) error) error {
+	start := time.Now()
+	ctx, span := c.tracer.Start(ctx, "vectorxlite."+name)
+	defer span.End()
+	span.SetAttributes(attrs...)
+
+	err := fn(ctx)
+
+	statusCode := grpcCodeString(err)
+	labels := append(append([]attribute.KeyValue{}, attrs...),
+		attribute.String("rpc", name),
+		attribute.String("status_code", statusCode),
+	)
+	c.requestDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(labels...))
+	c.requestTotal.Add(ctx, 1, metric.WithAttributes(labels...))
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// grpcCodeString extracts the gRPC status code name from err, or "OK" when
+// err is nil.
+func grpcCodeString(err error) string {
+	if err == nil {
+		return "OK"
+	}
+	return grpcstatus.Code(err).String()
+}
+
+// Insert instruments client.Client.Insert with a "vectorxlite.Insert" span.
+func (c *Client) Insert(ctx context.Context, p *types.InsertPoint) error {
+	var attrs []attribute.KeyValue
+	if p != nil {
+		attrs = []attribute.KeyValue{
+			attribute.String("collection.name", p.CollectionName),
+			attribute.Int("vector.dim", len(p.Vector)),
+		}
+	}
+	return c.instrument(ctx, "Insert", attrs, func(ctx context.Context) error {
+		return c.Client.Insert(ctx, p)
+	})
+}
+
+// Search instruments client.Client.Search with a "vectorxlite.Search" span.
+func (c *Client) Search(ctx context.Context, q *types.SearchPoint) (*types.SearchResponse, error) {
+	var attrs []attribute.KeyValue
+	if q != nil {
+		attrs = []attribute.KeyValue{
+			attribute.String("collection.name", q.CollectionName),
+			attribute.Int("vector.dim", len(q.Vector)),
+			attribute.Int("topk", int(q.TopK)),
+			attribute.Bool("payload.query.present", q.PayloadSearchQuery != ""),
+		}
+	}
+
+	var resp *types.SearchResponse
+	err := c.instrument(ctx, "Search", attrs, func(ctx context.Context) error {
+		var innerErr error
+		resp, innerErr = c.Client.Search(ctx, q)
+		return innerErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("results.count", len(resp.Results)))
+	return resp, nil
+}
+
+// CreateCollection instruments client.Client.CreateCollection.
+func (c *Client) CreateCollection(ctx context.Context, cfg *types.CollectionConfig) error {
+	var attrs []attribute.KeyValue
+	if cfg != nil {
+		attrs = []attribute.KeyValue{attribute.String("collection.name", cfg.CollectionName)}
+	}
+	return c.instrument(ctx, "CreateCollection", attrs, func(ctx context.Context) error {
+		return c.Client.CreateCollection(ctx, cfg)
+	})
+}
+
+// CollectionExists instruments client.Client.CollectionExists.
+func (c *Client) CollectionExists(ctx context.Context, collectionName string) (bool, error) {
+	attrs := []attribute.KeyValue{attribute.String("collection.name", collectionName)}
+	var exists bool
+	err := c.instrument(ctx, "CollectionExists", attrs, func(ctx context.Context) error {
+		var innerErr error
+		exists, innerErr = c.Client.CollectionExists(ctx, collectionName)
+		return innerErr
+	})
+	return exists, err
+}
+
+// UnaryClientInterceptor returns a grpc.UnaryClientInterceptor recording
+// the same span and metrics this package's Client produces, for callers
+// who build their own grpc.ClientConn and want just the instrumentation
+// installed as a dial option.
+func UnaryClientInterceptor(tp trace.TracerProvider, mp metric.MeterProvider) grpc.UnaryClientInterceptor {
+	tracer := tp.Tracer(instrumentationName)
+	duration, total := mustInstruments(mp)
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		ctx, span := tracer.Start(ctx, method)
+		defer span.End()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		labels := []attribute.KeyValue{
+			attribute.String("rpc", method),
+			attribute.String("status_code", grpcCodeString(err)),
+		}
+		duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(labels...))
+		total.Add(ctx, 1, metric.WithAttributes(labels...))
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/uttom-akash/vector-xlite/standalone/clients/go/pb"
+	types "github.com/uttom-akash/vector-xlite/standalone/clients/go/types"
+)
+
+// AlterRetentionPolicy updates the retention policy of an existing
+// collection. Vectors older than policy.Duration are deleted by the
+// server's background sweeper on the next sweep.
+func (c *Client) AlterRetentionPolicy(ctx context.Context, collectionName string, policy types.RetentionPolicy) error {
+	if collectionName == "" {
+		return errors.New("collection name cannot be empty")
+	}
+	pbReq := &pb.AlterRetentionPolicyRequestPB{
+		CollectionName: collectionName,
+		Policy: &pb.RetentionPolicyPB{
+			DurationMs:      policy.MarshalPB().DurationMs,
+			ShardDurationMs: policy.MarshalPB().ShardDurationMs,
+		},
+	}
+	return retryErr(ctx, c, false, func() error {
+		_, err := c.pbClient.AlterRetentionPolicy(ctx, pbReq)
+		return err
+	})
+}
+
+// GetRetentionPolicy returns the retention policy currently configured for
+// a collection.
+func (c *Client) GetRetentionPolicy(ctx context.Context, collectionName string) (types.RetentionPolicy, error) {
+	if collectionName == "" {
+		return types.RetentionPolicy{}, errors.New("collection name cannot be empty")
+	}
+	pbReq := &pb.GetRetentionPolicyRequestPB{
+		CollectionName: collectionName,
+	}
+	pbResp, err := retryCall(ctx, c, false, func() (*pb.GetRetentionPolicyResponsePB, error) {
+		return c.pbClient.GetRetentionPolicy(ctx, pbReq)
+	})
+	if err != nil {
+		return types.RetentionPolicy{}, err
+	}
+	return types.UnmarshalRetentionPolicyPB(&types.RetentionPolicyPB{
+		DurationMs:      pbResp.Policy.DurationMs,
+		ShardDurationMs: pbResp.Policy.ShardDurationMs,
+	}), nil
+}
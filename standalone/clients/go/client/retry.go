@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy configures automatic retry of transient RPC failures across
+// every Client method. Nil (the default) disables retry entirely.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first
+	// (default: 1, i.e. no retry).
+	MaxAttempts int
+	// InitialInterval is the delay before the first retry (default: 100ms).
+	InitialInterval time.Duration
+	// MaxInterval caps the delay regardless of Multiplier (default: 5s).
+	MaxInterval time.Duration
+	// Multiplier grows the delay each attempt (default: 2).
+	Multiplier float64
+	// Jitter scales the delay by a uniform random factor in
+	// [1-Jitter, 1+Jitter] (default: 0, no jitter).
+	Jitter float64
+	// RetryableCodes lists the gRPC status codes worth retrying (default:
+	// Unavailable, DeadlineExceeded, Aborted, ResourceExhausted).
+	RetryableCodes []codes.Code
+	// AllowUnsafeRetry must be set to retry non-idempotent operations
+	// (Insert, Delete), acknowledging the risk of duplicate effects.
+	AllowUnsafeRetry bool
+	// OnRetry, if set, is called right before each retry sleep.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+func defaultRetryableCodes() []codes.Code {
+	return []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.ResourceExhausted}
+}
+
+// retryable reports whether err's gRPC status code is in p.RetryableCodes.
+func (p *RetryPolicy) retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	codesList := p.RetryableCodes
+	if len(codesList) == 0 {
+		codesList = defaultRetryableCodes()
+	}
+	for _, c := range codesList {
+		if st.Code() == c {
+			return true
+		}
+	}
+	return false
+}
+
+// delay computes the backoff before the given zero-indexed retry attempt.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	initial := p.InitialInterval
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	maxInterval := p.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 5 * time.Second
+	}
+
+	d := time.Duration(float64(initial) * math.Pow(multiplier, float64(attempt)))
+	if d > maxInterval {
+		d = maxInterval
+	}
+	if p.Jitter > 0 {
+		factor := 1 - p.Jitter + rand.Float64()*2*p.Jitter
+		d = time.Duration(float64(d) * factor)
+	}
+	return d
+}
+
+// retryCall runs fn under c's RetryPolicy, retrying transient failures with
+// exponential backoff and jitter. unsafe marks non-idempotent operations,
+// which only retry when AllowUnsafeRetry is set. Retry sleeps respect
+// ctx.Done() so callers aren't blocked past their own deadline.
+func retryCall[T any](ctx context.Context, c *Client, unsafe bool, fn func() (T, error)) (T, error) {
+	policy := c.retryPolicy
+	if policy == nil || (unsafe && !policy.AllowUnsafeRetry) {
+		return fn()
+	}
+
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	var zero T
+	var result T
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		result, err = fn()
+		if err == nil {
+			return result, nil
+		}
+		if !policy.retryable(err) || attempt == maxAttempts-1 {
+			return zero, err
+		}
+
+		d := policy.delay(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, d)
+		}
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case <-time.After(d):
+		}
+	}
+	return zero, err
+}
+
+// retryErr is the error-only counterpart of retryCall, for methods that
+// don't return a value alongside the error.
+func retryErr(ctx context.Context, c *Client, unsafe bool, fn func() error) error {
+	_, err := retryCall(ctx, c, unsafe, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
@@ -59,9 +59,16 @@ func (c *Client) ExportSnapshot(ctx context.Context, req *types.ExportSnapshotRe
 	return chunkChan, errChan
 }
 
-// ExportSnapshotSync exports a snapshot and collects all chunks synchronously.
-// This is a convenience method for simple use cases.
+// ExportSnapshotSync exports a snapshot and collects all chunks
+// synchronously. This is a convenience method for simple use cases. Reads
+// are idempotent, so the whole export is retried on a transient failure.
 func (c *Client) ExportSnapshotSync(ctx context.Context, req *types.ExportSnapshotRequest) (*types.SnapshotCollector, error) {
+	return retryCall(ctx, c, false, func() (*types.SnapshotCollector, error) {
+		return c.exportSnapshotSyncOnce(ctx, req)
+	})
+}
+
+func (c *Client) exportSnapshotSyncOnce(ctx context.Context, req *types.ExportSnapshotRequest) (*types.SnapshotCollector, error) {
 	collector := types.NewSnapshotCollector()
 
 	chunkChan, errChan := c.ExportSnapshot(ctx, req)
@@ -89,13 +96,21 @@ func (c *Client) ExportSnapshotSync(ctx context.Context, req *types.ExportSnapsh
 	}
 }
 
-// ImportSnapshot imports a snapshot from a slice of chunks.
-// This is used by Raft followers to restore state from a leader's snapshot.
+// ImportSnapshot imports a snapshot from a slice of chunks. This is used by
+// Raft followers to restore state from a leader's snapshot. A full restore
+// isn't idempotent mid-stream, so retry only happens when the client's
+// RetryPolicy sets AllowUnsafeRetry.
 func (c *Client) ImportSnapshot(ctx context.Context, chunks []*types.SnapshotChunk) (*types.ImportSnapshotResponse, error) {
 	if len(chunks) == 0 {
 		return nil, errors.New("no chunks to import")
 	}
 
+	return retryCall(ctx, c, true, func() (*types.ImportSnapshotResponse, error) {
+		return c.importSnapshotOnce(ctx, chunks)
+	})
+}
+
+func (c *Client) importSnapshotOnce(ctx context.Context, chunks []*types.SnapshotChunk) (*types.ImportSnapshotResponse, error) {
 	// Start the streaming RPC
 	stream, err := c.pbClient.ImportSnapshot(ctx)
 	if err != nil {
@@ -177,6 +192,7 @@ func convertPbChunkToType(pbChunk *pb.SnapshotChunkPB) *types.SnapshotChunk {
 			Offset:      pbChunk.FileChunk.Offset,
 			Data:        pbChunk.FileChunk.Data,
 			IsLastChunk: pbChunk.FileChunk.IsLastChunk,
+			Checksum:    pbChunk.FileChunk.Checksum,
 		}
 	}
 
@@ -218,6 +234,7 @@ func convertTypeChunkToPb(chunk *types.SnapshotChunk) *pb.SnapshotChunkPB {
 			Offset:      chunk.FileChunk.Offset,
 			Data:        chunk.FileChunk.Data,
 			IsLastChunk: chunk.FileChunk.IsLastChunk,
+			Checksum:    chunk.FileChunk.Checksum,
 		}
 	}
 
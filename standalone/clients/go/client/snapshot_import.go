@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"time"
+
+	pb "github.com/uttom-akash/vector-xlite/go_grpc_client/pb"
+	types "github.com/uttom-akash/vector-xlite/go_grpc_client/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const maxSnapshotReconnectAttempts = 5
+
+// ImportSnapshotFromLeader pulls a snapshot directly from a Raft leader and
+// pipes it into the local ImportSnapshot handler without buffering the
+// whole snapshot in memory. Each chunk's CRC32C is verified against
+// pbChunk.FileChunk.Checksum before it is forwarded. If the stream drops,
+// it reconnects and resumes from the last chunk offset that was
+// successfully forwarded per file, rather than restarting the transfer.
+func (c *Client) ImportSnapshotFromLeader(ctx context.Context, req *types.ImportFromLeaderRequest) (*types.ImportSnapshotResponse, error) {
+	if req == nil || req.LeaderAddr == "" {
+		return nil, errors.New("leader address required")
+	}
+
+	resumeToken := make(map[string]uint64, len(req.ResumeToken))
+	for file, offset := range req.ResumeToken {
+		resumeToken[file] = offset
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxSnapshotReconnectAttempts; attempt++ {
+		resp, applied, err := c.importSnapshotFromLeaderOnce(ctx, req.LeaderAddr, resumeToken)
+		for file, offset := range applied {
+			resumeToken[file] = offset
+		}
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+		time.Sleep(snapshotReconnectBackoff(attempt))
+	}
+
+	return nil, fmt.Errorf("import snapshot from leader: exhausted %d reconnect attempts: %w", maxSnapshotReconnectAttempts, lastErr)
+}
+
+// snapshotReconnectBackoff returns an exponential backoff capped at 5s.
+func snapshotReconnectBackoff(attempt int) time.Duration {
+	d := 200 * time.Millisecond * time.Duration(1<<attempt)
+	if d > 5*time.Second {
+		d = 5 * time.Second
+	}
+	return d
+}
+
+// importSnapshotFromLeaderOnce opens one ExportSnapshot stream against the
+// leader and forwards every chunk straight into the local ImportSnapshot
+// stream, returning the per-file offsets that were successfully forwarded
+// so a retry can resume instead of starting over.
+func (c *Client) importSnapshotFromLeaderOnce(ctx context.Context, leaderAddr string, resumeToken map[string]uint64) (*types.ImportSnapshotResponse, map[string]uint64, error) {
+	applied := make(map[string]uint64)
+
+	conn, err := grpc.DialContext(ctx, leaderAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	if err != nil {
+		return nil, applied, fmt.Errorf("dial leader: %w", err)
+	}
+	defer conn.Close()
+	leaderClient := pb.NewVectorXLitePBClient(conn)
+
+	exportStream, err := leaderClient.ExportSnapshot(ctx, &pb.ExportSnapshotRequestPB{
+		IncludeIndexFiles: true,
+		ResumeFrom:        resumeToken,
+	})
+	if err != nil {
+		return nil, applied, fmt.Errorf("open export stream: %w", err)
+	}
+
+	importStream, err := c.pbClient.ImportSnapshot(ctx)
+	if err != nil {
+		return nil, applied, fmt.Errorf("open import stream: %w", err)
+	}
+
+	for {
+		pbChunk, err := exportStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, applied, fmt.Errorf("receive chunk: %w", err)
+		}
+
+		chunk := convertPbChunkToType(pbChunk)
+		if fc := chunk.FileChunk; fc != nil && fc.Checksum != "" {
+			if got := crc32Hex(fc.Data); got != fc.Checksum {
+				return nil, applied, fmt.Errorf("chunk checksum mismatch for %s at offset %d: want %s, got %s", fc.FileName, fc.Offset, fc.Checksum, got)
+			}
+		}
+
+		if err := importStream.Send(convertTypeChunkToPb(chunk)); err != nil {
+			return nil, applied, fmt.Errorf("forward chunk: %w", err)
+		}
+
+		if fc := chunk.FileChunk; fc != nil {
+			applied[fc.FileName] = fc.Offset + uint64(len(fc.Data))
+		}
+		if chunk.IsFinal {
+			break
+		}
+	}
+
+	pbResp, err := importStream.CloseAndRecv()
+	if err != nil {
+		return nil, applied, fmt.Errorf("close import stream: %w", err)
+	}
+
+	return &types.ImportSnapshotResponse{
+		Success:       pbResp.Success,
+		ErrorMessage:  pbResp.ErrorMessage,
+		SnapshotID:    pbResp.SnapshotId,
+		BytesRestored: pbResp.BytesRestored,
+		FilesRestored: pbResp.FilesRestored,
+	}, applied, nil
+}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// crc32Hex returns the hex-encoded CRC32C of data.
+func crc32Hex(data []byte) string {
+	return fmt.Sprintf("%08x", crc32.Checksum(data, crc32cTable))
+}
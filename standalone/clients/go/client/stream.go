@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	pb "github.com/uttom-akash/vector-xlite/standalone/clients/go/pb"
+	types "github.com/uttom-akash/vector-xlite/standalone/clients/go/types"
+)
+
+// InsertStreamOptions are server-side batching hints sent in the initial
+// message of an InsertStream, letting the server flush inserted points in
+// chunks instead of one commit per point.
+type InsertStreamOptions struct {
+	// FlushEvery flushes after this many buffered points. 0 disables the
+	// count-based flush.
+	FlushEvery int
+	// FlushInterval flushes on a timer regardless of buffered count. 0
+	// disables the time-based flush.
+	FlushInterval time.Duration
+}
+
+// InsertStream is a handle to an open client-streaming Insert RPC: call
+// Send for each point, then CloseAndRecv once to get the ack summary.
+type InsertStream struct {
+	stream pb.VectorXLitePB_InsertStreamClient
+}
+
+// InsertStream opens a client-streaming Insert RPC, reducing per-call
+// overhead for workloads doing many inserts per second. The unary Insert
+// remains a thin single-point wrapper over the unary RPC for callers that
+// don't need this.
+func (c *Client) InsertStream(ctx context.Context, opts InsertStreamOptions) (*InsertStream, error) {
+	stream, err := c.pbClient.InsertStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	init := &pb.InsertStreamRequestPB{
+		Init: &pb.InsertStreamInitPB{
+			FlushEvery:      int32(opts.FlushEvery),
+			FlushIntervalMs: opts.FlushInterval.Milliseconds(),
+		},
+	}
+	if err := stream.Send(init); err != nil {
+		return nil, err
+	}
+	return &InsertStream{stream: stream}, nil
+}
+
+// Send queues a point for insertion. Safe to call repeatedly; the server
+// flushes according to the FlushEvery/FlushInterval hints given to
+// InsertStream.
+func (s *InsertStream) Send(p *types.InsertPoint) error {
+	if p == nil {
+		return errors.New("nil point")
+	}
+	return s.stream.Send(&pb.InsertStreamRequestPB{
+		Point: &pb.InsertPointPB{
+			CollectionName:     p.CollectionName,
+			Id:                 p.Id,
+			Vector:             p.Vector,
+			PayloadInsertQuery: p.PayloadInsertQuery,
+		},
+	})
+}
+
+// CloseAndRecv closes the send side and waits for the server's single ack
+// summary covering every point sent on the stream.
+func (s *InsertStream) CloseAndRecv() (*types.InsertStreamSummary, error) {
+	resp, err := s.stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+	return &types.InsertStreamSummary{
+		Inserted: resp.Inserted,
+		Failed:   resp.Failed,
+	}, nil
+}
+
+// SearchStream opens a server-streaming Search RPC and returns top-K
+// results as they are produced on the server, rather than waiting for the
+// full sorted response. The unary Search remains a thin wrapper over the
+// unary RPC for callers that just want the final slice.
+func (c *Client) SearchStream(ctx context.Context, q *types.SearchPoint) (<-chan types.SearchResultItem, <-chan error) {
+	itemChan := make(chan types.SearchResultItem, 32)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(itemChan)
+		defer close(errChan)
+
+		if q == nil {
+			errChan <- errors.New("nil search point")
+			return
+		}
+
+		pbReq := &pb.SearchPointPB{
+			CollectionName:     q.CollectionName,
+			Vector:             q.Vector,
+			TopK:               q.TopK,
+			PayloadSearchQuery: q.PayloadSearchQuery,
+			Consistency:        q.Consistency.Level.String(),
+			MaxLagMs:           q.Consistency.MaxLagMs,
+			FilterExpression:   q.Filter,
+			SearchStrategy:     q.Strategy.String(),
+			FilterMultiplier:   q.FilterMultiplier,
+		}
+
+		stream, err := c.pbClient.SearchStream(ctx, pbReq)
+		if err != nil {
+			errChan <- err
+			return
+		}
+
+		for {
+			pbItem, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				errChan <- err
+				return
+			}
+
+			item := types.SearchResultItem{
+				Rowid:    pbItem.Rowid,
+				Distance: pbItem.Distance,
+				Payload:  make([]types.KeyValue, 0, len(pbItem.Payload)),
+			}
+			for _, kv := range pbItem.Payload {
+				item.Payload = append(item.Payload, types.KeyValue{Key: kv.Key, Value: kv.Value})
+			}
+			itemChan <- item
+		}
+	}()
+
+	return itemChan, errChan
+}
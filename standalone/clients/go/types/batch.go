@@ -0,0 +1,45 @@
+package types
+
+import "time"
+
+// RetryPolicy configures per-request retries within a batch call.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries per request, including the
+	// first (default: 1, i.e. no retry).
+	MaxAttempts int
+	// Backoff is the fixed delay between attempts.
+	Backoff time.Duration
+}
+
+// BatchOptions configures InsertBatch / SearchBatch concurrency and retries.
+type BatchOptions struct {
+	// MaxInFlight bounds the number of concurrent requests (default: 20).
+	MaxInFlight int
+	// PerRequestTimeout, when > 0, wraps each individual request in its own
+	// context.WithTimeout derived from the call's context.
+	PerRequestTimeout time.Duration
+	// StopOnError cancels dispatch of not-yet-started requests as soon as
+	// one fails. Requests already in flight still run to completion.
+	StopOnError bool
+	// RetryPolicy, if set, retries a failing request before recording it
+	// as an error.
+	RetryPolicy *RetryPolicy
+}
+
+// BatchResult maps the original slice index of each failed request to its
+// error. An index with no entry succeeded.
+type BatchResult struct {
+	Errors map[int]error
+}
+
+// HasErrors reports whether any request in the batch failed.
+func (r *BatchResult) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// SearchBatchItem pairs a SearchBatch response with its original index.
+type SearchBatchItem struct {
+	Index    int
+	Response *SearchResponse
+	Err      error
+}
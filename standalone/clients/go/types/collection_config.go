@@ -8,6 +8,13 @@ type CollectionConfig struct {
 	VectorDimension    uint32
 	PayloadTableSchema string
 	IndexFilePath      string
+	// VectorEncoding selects the storage representation. Would only be
+	// meaningful for DistanceHamming/DistanceJaccard collections, but
+	// Build rejects both distances as unimplemented, so this field
+	// currently has no effect regardless of its value.
+	VectorEncoding VectorEncoding
+	// Retention controls automatic expiry of vectors in this collection.
+	Retention RetentionPolicy
 }
 
 type CollectionConfigBuilder struct {
@@ -43,6 +50,14 @@ func (b *CollectionConfigBuilder) IndexFilePath(p string) *CollectionConfigBuild
 	return b
 }
 
+// VectorEncoding sets the storage representation for Hamming/Jaccard
+// collections. Currently has no effect for any distance function, since
+// Build rejects Hamming/Jaccard outright until a scorer exists for them.
+func (b *CollectionConfigBuilder) VectorEncoding(e VectorEncoding) *CollectionConfigBuilder {
+	b.cfg.VectorEncoding = e
+	return b
+}
+
 func (b *CollectionConfigBuilder) Build() (*CollectionConfig, error) {
 	if b.cfg.CollectionName == "" {
 		return nil, errors.New("collection_name required")
@@ -50,5 +65,11 @@ func (b *CollectionConfigBuilder) Build() (*CollectionConfig, error) {
 	if b.cfg.VectorDimension == 0 {
 		return nil, errors.New("vector_dimension must be > 0")
 	}
+	if b.cfg.Distance == DistanceManhattan || b.cfg.Distance == DistanceHamming || b.cfg.Distance == DistanceJaccard {
+		return nil, errors.New("distance " + b.cfg.Distance.String() + " is not yet implemented: no scorer exists for it")
+	}
+	if b.cfg.Retention.ShardDuration > 0 && b.cfg.Retention.Duration > 0 && b.cfg.Retention.ShardDuration > b.cfg.Retention.Duration {
+		return nil, errShardLongerThanRetention
+	}
 	return &b.cfg, nil
 }
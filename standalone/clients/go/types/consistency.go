@@ -0,0 +1,62 @@
+package types
+
+// ConsistencyLevel controls how fresh a Search result must be relative to
+// the collection's write path.
+type ConsistencyLevel int
+
+const (
+	// ConsistencyEventual serves the search from whichever node received
+	// it, with no freshness guarantee. This is the pre-existing behavior.
+	ConsistencyEventual ConsistencyLevel = iota
+	// ConsistencyBoundedStaleness serves from a follower only if its
+	// applied Raft index is within MaxLagMs of the leader's committed
+	// index; otherwise the request is forwarded to the leader.
+	ConsistencyBoundedStaleness
+	// ConsistencyStrong issues a ReadIndex barrier to the leader before
+	// serving the search locally.
+	ConsistencyStrong
+	// ConsistencyLeader always redirects the search to the leader.
+	ConsistencyLeader
+)
+
+func (c ConsistencyLevel) String() string {
+	switch c {
+	case ConsistencyBoundedStaleness:
+		return "BoundedStaleness"
+	case ConsistencyStrong:
+		return "Strong"
+	case ConsistencyLeader:
+		return "Leader"
+	default:
+		return "Eventual"
+	}
+}
+
+// Consistency bundles a ConsistencyLevel with the staleness bound used by
+// ConsistencyBoundedStaleness.
+type Consistency struct {
+	Level    ConsistencyLevel
+	MaxLagMs int64
+}
+
+// Eventual is the default, pre-existing consistency level.
+func Eventual() Consistency { return Consistency{Level: ConsistencyEventual} }
+
+// BoundedStaleness serves from a follower only if it is within maxLagMs of
+// the leader's committed index.
+func BoundedStaleness(maxLagMs int64) Consistency {
+	return Consistency{Level: ConsistencyBoundedStaleness, MaxLagMs: maxLagMs}
+}
+
+// Strong requires a ReadIndex barrier before serving.
+func Strong() Consistency { return Consistency{Level: ConsistencyStrong} }
+
+// Leader always redirects to the leader.
+func Leader() Consistency { return Consistency{Level: ConsistencyLeader} }
+
+// Consistency sets the freshness requirement for this search. Defaults to
+// Eventual (current node, no wait) when not called.
+func (b *SearchPointBuilder) Consistency(c Consistency) *SearchPointBuilder {
+	b.s.Consistency = c
+	return b
+}
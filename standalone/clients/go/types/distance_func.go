@@ -7,6 +7,17 @@ const (
 	DistanceCosine
 	DistanceEuclidean
 	DistanceDot
+	// DistanceManhattan, DistanceHamming, and DistanceJaccard are enum
+	// values only: no index scorer in this tree (the scoring engine lives
+	// outside this repo, behind VectorClient) implements them yet, and
+	// there is no packed-binary storage path to back Hamming/Jaccard's
+	// VectorEncoding. CollectionConfigBuilder.Build rejects all three
+	// rather than silently accepting a collection that search can't
+	// actually score. Remove that rejection once a real scorer and
+	// storage path exist for each.
+	DistanceManhattan
+	DistanceHamming
+	DistanceJaccard
 )
 
 func (d DistanceFunction) String() string {
@@ -17,7 +28,38 @@ func (d DistanceFunction) String() string {
 		return "L2"
 	case DistanceDot:
 		return "IP"
+	case DistanceManhattan:
+		return "L1"
+	case DistanceHamming:
+		return "Hamming"
+	case DistanceJaccard:
+		return "Jaccard"
 	default:
 		return "Unknown"
 	}
 }
+
+// VectorEncoding selects the on-disk representation for a collection's
+// vectors. Hamming and Jaccard distances would operate on packed bits
+// rather than float32 components, so collections using them should
+// eventually pick Binary or UInt8 to avoid storing a wasteful float32
+// array — but no storage path for either encoding exists yet (see
+// DistanceHamming/DistanceJaccard), so this currently has no effect.
+type VectorEncoding int
+
+const (
+	VectorEncodingFloat32 VectorEncoding = iota
+	VectorEncodingBinary
+	VectorEncodingUInt8
+)
+
+func (e VectorEncoding) String() string {
+	switch e {
+	case VectorEncodingBinary:
+		return "Binary"
+	case VectorEncodingUInt8:
+		return "UInt8"
+	default:
+		return "Float32"
+	}
+}
@@ -40,3 +40,10 @@ func (b *InsertPointBuilder) Build() (*InsertPoint, error) {
 	}
 	return &b.p, nil
 }
+
+// InsertStreamSummary is the single ack returned after a client-streaming
+// InsertStream call closes, covering every point sent on the stream.
+type InsertStreamSummary struct {
+	Inserted uint32
+	Failed   uint32
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+// RetentionPolicy describes how long vectors in a collection are kept
+// before the background sweeper deletes them, modeled on InfluxDB's
+// RetentionPolicyInfo.
+type RetentionPolicy struct {
+	// Duration is how long a vector is retained after insertion. Zero means
+	// no automatic expiry.
+	Duration time.Duration
+	// ShardDuration groups vectors into time-bucketed shards so the sweeper
+	// can drop whole shards instead of issuing per-row deletes.
+	ShardDuration time.Duration
+}
+
+// RetentionPolicyPB is the wire representation of RetentionPolicy.
+type RetentionPolicyPB struct {
+	DurationMs      int64 `json:"duration_ms"`
+	ShardDurationMs int64 `json:"shard_duration_ms"`
+}
+
+// MarshalPB converts a RetentionPolicy to its wire representation.
+func (p RetentionPolicy) MarshalPB() *RetentionPolicyPB {
+	return &RetentionPolicyPB{
+		DurationMs:      p.Duration.Milliseconds(),
+		ShardDurationMs: p.ShardDuration.Milliseconds(),
+	}
+}
+
+// UnmarshalRetentionPolicyPB converts the wire representation back to a
+// RetentionPolicy.
+func UnmarshalRetentionPolicyPB(pb *RetentionPolicyPB) RetentionPolicy {
+	if pb == nil {
+		return RetentionPolicy{}
+	}
+	return RetentionPolicy{
+		Duration:      time.Duration(pb.DurationMs) * time.Millisecond,
+		ShardDuration: time.Duration(pb.ShardDurationMs) * time.Millisecond,
+	}
+}
+
+// RetentionPolicy sets the collection's retention policy. Leave unset to
+// retain vectors indefinitely.
+func (b *CollectionConfigBuilder) RetentionPolicy(p RetentionPolicy) *CollectionConfigBuilder {
+	b.cfg.Retention = p
+	return b
+}
+
+var errShardLongerThanRetention = errors.New("retention_policy: shard_duration must not exceed duration")
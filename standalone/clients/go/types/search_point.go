@@ -0,0 +1,104 @@
+package types
+
+import "errors"
+
+// SearchStrategy selects how Filter is combined with ANN traversal.
+type SearchStrategy int
+
+const (
+	// StrategyPostFilter over-fetches TopK*FilterMultiplier candidates by
+	// ANN distance, then drops any that don't match Filter. Cheaper, but
+	// approximate: if fewer than TopK candidates match, results come up
+	// short rather than widening the search.
+	StrategyPostFilter SearchStrategy = iota
+	// StrategyPreFilter runs Filter against the payload table first to get
+	// a candidate rowid set, then restricts ANN traversal to it. Exact,
+	// but costs a SQL query up front and requires the index to accept a
+	// rowid-set constraint.
+	StrategyPreFilter
+)
+
+func (s SearchStrategy) String() string {
+	switch s {
+	case StrategyPreFilter:
+		return "PreFilter"
+	default:
+		return "PostFilter"
+	}
+}
+
+type SearchPoint struct {
+	CollectionName     string
+	Vector             []float32
+	TopK               uint32
+	PayloadSearchQuery string // optional SQL to fetch/filter payload
+	Consistency        Consistency
+	// Filter is a SQL WHERE-clause predicate over the payload table (e.g.
+	// "age > 30 AND country = 'US'"), evaluated according to Strategy
+	// instead of being applied after the fact like PayloadSearchQuery.
+	Filter string
+	// Strategy selects how Filter is combined with ANN traversal. Defaults
+	// to StrategyPostFilter.
+	Strategy SearchStrategy
+	// FilterMultiplier controls how many extra candidates StrategyPostFilter
+	// over-fetches, as a multiple of TopK. Defaults to 3 when Filter is set.
+	FilterMultiplier uint32
+}
+
+type SearchPointBuilder struct {
+	s SearchPoint
+}
+
+func NewSearchPointBuilder() *SearchPointBuilder { return &SearchPointBuilder{} }
+
+func (b *SearchPointBuilder) CollectionName(n string) *SearchPointBuilder {
+	b.s.CollectionName = n
+	return b
+}
+func (b *SearchPointBuilder) Vector(v []float32) *SearchPointBuilder {
+	b.s.Vector = v
+	return b
+}
+func (b *SearchPointBuilder) TopK(k uint32) *SearchPointBuilder {
+	b.s.TopK = k
+	return b
+}
+func (b *SearchPointBuilder) PayloadSearchQuery(q string) *SearchPointBuilder {
+	b.s.PayloadSearchQuery = q
+	return b
+}
+
+// Filter sets a SQL WHERE-clause predicate to prune candidates before or
+// after ANN scoring, depending on Strategy.
+func (b *SearchPointBuilder) Filter(expr string) *SearchPointBuilder {
+	b.s.Filter = expr
+	return b
+}
+
+// Strategy selects how Filter is combined with ANN traversal.
+func (b *SearchPointBuilder) Strategy(s SearchStrategy) *SearchPointBuilder {
+	b.s.Strategy = s
+	return b
+}
+
+// FilterMultiplier sets the over-fetch multiple used by StrategyPostFilter.
+func (b *SearchPointBuilder) FilterMultiplier(m uint32) *SearchPointBuilder {
+	b.s.FilterMultiplier = m
+	return b
+}
+
+func (b *SearchPointBuilder) Build() (*SearchPoint, error) {
+	if b.s.CollectionName == "" {
+		return nil, errors.New("collection_name required")
+	}
+	if len(b.s.Vector) == 0 {
+		return nil, errors.New("vector required")
+	}
+	if b.s.TopK == 0 {
+		b.s.TopK = 10
+	}
+	if b.s.Filter != "" && b.s.FilterMultiplier == 0 {
+		b.s.FilterMultiplier = 3
+	}
+	return &b.s, nil
+}
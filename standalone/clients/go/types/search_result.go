@@ -13,4 +13,19 @@ type SearchResultItem struct {
 
 type SearchResponse struct {
 	Results []SearchResultItem
+	// ServedByLeader reports whether this search was ultimately executed on
+	// the leader (either because the client was connected there already or
+	// because the node redirected for ConsistencyStrong/ConsistencyLeader).
+	ServedByLeader bool
+	// StalenessMs is how far behind the leader's committed index this
+	// node's applied index was when the search ran, in milliseconds. Always
+	// 0 for ConsistencyStrong/ConsistencyLeader.
+	StalenessMs int64
+	// CandidatesExamined is how many rows the index traversal visited
+	// before producing Results. Only meaningful when Filter was set.
+	CandidatesExamined uint32
+	// FilterMatched is how many of those candidates matched Filter. For
+	// StrategyPostFilter, FilterMatched < TopK means the query ran out of
+	// over-fetched candidates before filling TopK results.
+	FilterMatched uint32
 }
@@ -87,6 +87,10 @@ func main() {
 	// Example 3: Import snapshot (follower recovery)
 	fmt.Println("\n=== Example 3: Import Snapshot ===")
 	importExample(ctx, client)
+
+	// Example 4: Import snapshot directly from a leader (real follower bootstrap)
+	fmt.Println("\n=== Example 4: Import Snapshot From Leader ===")
+	importFromLeaderExample(ctx, client)
 }
 
 // syncExportExample demonstrates synchronous snapshot export
@@ -211,3 +215,23 @@ func importExample(ctx context.Context, c *client.Client) {
 		fmt.Printf("Import failed: %s\n", resp.ErrorMessage)
 	}
 }
+
+// importFromLeaderExample demonstrates how a real follower would bootstrap:
+// pull the snapshot straight off the leader's gRPC address instead of
+// exporting and re-feeding a local collector, resuming from a prior partial
+// transfer if ResumeToken is non-empty.
+func importFromLeaderExample(ctx context.Context, c *client.Client) {
+	req := &types.ImportFromLeaderRequest{
+		LeaderAddr: "127.0.0.1:50051",
+	}
+	resp, err := c.ImportSnapshotFromLeader(ctx, req)
+	if err != nil {
+		log.Printf("Import from leader failed: %v", err)
+		return
+	}
+
+	fmt.Printf("Import from leader successful!\n")
+	fmt.Printf("  Snapshot ID: %s\n", resp.SnapshotID)
+	fmt.Printf("  Bytes restored: %d\n", resp.BytesRestored)
+	fmt.Printf("  Files restored: %d\n", resp.FilesRestored)
+}
@@ -17,6 +17,15 @@ const (
 	node3ClusterAddr = "localhost:5022"
 	dialTimeout      = 5 * time.Second
 	requestTimeout   = 10 * time.Second
+
+	// node4ClusterAddr is assumed to be a freshly started, not-yet-joined
+	// node, for TestJoinCatchesUpViaSnapshot. Start it with an aggressive
+	// -snapshot-threshold/-trailing-logs so the leader has already
+	// compacted its log away by the time node4 joins.
+	node4ClusterAddr = "localhost:5032"
+	// node4RaftAddr is node4's Raft address, the one JoinCluster hands to
+	// Raft as the new member's address.
+	node4RaftAddr = "localhost:5031"
 )
 
 // TestClusterInfo tests retrieving cluster information from all nodes
@@ -54,6 +63,23 @@ func TestClusterInfo(t *testing.T) {
 			if info.LeaderAddr == "" {
 				t.Error("Cluster should have a leader")
 			}
+
+			// All fixture nodes are started as full voters, so Suffrage
+			// should report "Voter" for each, never the learner states.
+			for _, node := range info.Nodes {
+				if node.Suffrage != "Voter" {
+					t.Errorf("node %s: expected Suffrage \"Voter\", got %q", node.NodeId, node.Suffrage)
+				}
+			}
+
+			// Every fixture node is built from the same consensus package,
+			// so the cluster-wide minimum should equal what a single node
+			// advertises on its own: nothing has downgraded it.
+			if info.MinCapabilities == nil {
+				t.Error("expected MinCapabilities to be populated")
+			} else if info.MinCapabilities.ProtocolVersion == 0 {
+				t.Error("expected a non-zero MinCapabilities.ProtocolVersion")
+			}
 		})
 	}
 }
@@ -338,6 +364,138 @@ func TestReadFromFollowers(t *testing.T) {
 	}
 }
 
+// TestLinearizableReadFromFollower verifies that a Search with
+// Consistency: "Linearizable" issued against a follower still observes a
+// write that was just acknowledged, by way of the follower's ReadIndex
+// barrier, without needing to be redirected to the leader.
+func TestLinearizableReadFromFollower(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	c, err := client.NewClusterClientSimple(node1ClusterAddr)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer c.Close()
+
+	collectionName := fmt.Sprintf("linearizable_test_%d", time.Now().UnixNano())
+	schema := fmt.Sprintf("CREATE TABLE %s (rowid INTEGER PRIMARY KEY)", collectionName)
+
+	_, err = c.CreateCollection(ctx, &pb.CreateCollectionRequest{
+		CollectionName:     collectionName,
+		Distance:           "Cosine",
+		VectorDimension:    3,
+		PayloadTableSchema: schema,
+	})
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+
+	_, err = c.Insert(ctx, &pb.InsertRequest{
+		CollectionName: collectionName,
+		Id:             1,
+		Vector:         []float32{1.0, 0.0, 0.0},
+	})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	nodeAddrs := []string{node1ClusterAddr, node2ClusterAddr, node3ClusterAddr}
+	for _, addr := range nodeAddrs {
+		t.Run(fmt.Sprintf("LinearizableSearch_from_%s", addr), func(t *testing.T) {
+			nodeClient, err := client.NewClusterClientSimple(addr)
+			if err != nil {
+				t.Logf("Failed to connect to %s (might be down): %v", addr, err)
+				return
+			}
+			defer nodeClient.Close()
+
+			searchResp, err := nodeClient.Search(ctx, &pb.SearchRequest{
+				CollectionName: collectionName,
+				Vector:         []float32{1.0, 0.0, 0.0},
+				TopK:           5,
+				Consistency:    "Linearizable",
+			})
+			if err != nil {
+				t.Errorf("Linearizable search from %s failed: %v", addr, err)
+				return
+			}
+
+			if len(searchResp.Results) != 1 {
+				t.Errorf("Expected 1 result from %s immediately after insert, got %d", addr, len(searchResp.Results))
+			}
+		})
+	}
+}
+
+// TestLeaderOnlySearchRedirect verifies that a Search with Consistency:
+// "LeaderOnly" issued against a follower is transparently redirected to
+// the leader by ClusterClient's RedirectInterceptor, the same
+// FailedPrecondition/x-leader-addr path writes already use, exercised
+// here by LeaderRedirectInterceptor.Unary's requiresLeader check for
+// "LeaderOnly" rather than a write method.
+func TestLeaderOnlySearchRedirect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	c, err := client.NewClusterClientSimple(node1ClusterAddr)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer c.Close()
+
+	collectionName := fmt.Sprintf("leader_only_test_%d", time.Now().UnixNano())
+	schema := fmt.Sprintf("CREATE TABLE %s (rowid INTEGER PRIMARY KEY)", collectionName)
+
+	_, err = c.CreateCollection(ctx, &pb.CreateCollectionRequest{
+		CollectionName:     collectionName,
+		Distance:           "Cosine",
+		VectorDimension:    3,
+		PayloadTableSchema: schema,
+	})
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+
+	_, err = c.Insert(ctx, &pb.InsertRequest{
+		CollectionName: collectionName,
+		Id:             1,
+		Vector:         []float32{1.0, 0.0, 0.0},
+	})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	nodeAddrs := []string{node1ClusterAddr, node2ClusterAddr, node3ClusterAddr}
+	for _, addr := range nodeAddrs {
+		t.Run(fmt.Sprintf("LeaderOnlySearch_from_%s", addr), func(t *testing.T) {
+			nodeClient, err := client.NewClusterClientSimple(addr)
+			if err != nil {
+				t.Logf("Failed to connect to %s (might be down): %v", addr, err)
+				return
+			}
+			defer nodeClient.Close()
+
+			searchResp, err := nodeClient.Search(ctx, &pb.SearchRequest{
+				CollectionName: collectionName,
+				Vector:         []float32{1.0, 0.0, 0.0},
+				TopK:           5,
+				Consistency:    "LeaderOnly",
+			})
+			if err != nil {
+				t.Errorf("LeaderOnly search from %s failed: %v", addr, err)
+				return
+			}
+			if !searchResp.ServedByLeader {
+				t.Errorf("LeaderOnly search from %s should have been served by (or redirected to) the leader", addr)
+			}
+			if len(searchResp.Results) != 1 {
+				t.Errorf("Expected 1 result from %s, got %d", addr, len(searchResp.Results))
+			}
+		})
+	}
+}
+
 // TestConcurrentWrites tests concurrent write operations to the cluster
 func TestConcurrentWrites(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -486,6 +644,234 @@ func TestMultipleCollectionsInCluster(t *testing.T) {
 	}
 }
 
+// TestDeleteReplicatedViaRaft verifies that a Delete issued against the
+// leader is actually proposed through Raft (not just applied to the
+// leader's own local state): every follower should stop returning the
+// deleted point once replication catches up, the same way TestReadFromFollowers
+// verifies an Insert replicates.
+func TestDeleteReplicatedViaRaft(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	c, err := client.NewClusterClientSimple(node1ClusterAddr)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer c.Close()
+
+	collectionName := fmt.Sprintf("delete_test_%d", time.Now().UnixNano())
+	schema := fmt.Sprintf("CREATE TABLE %s (rowid INTEGER PRIMARY KEY)", collectionName)
+
+	_, err = c.CreateCollection(ctx, &pb.CreateCollectionRequest{
+		CollectionName:     collectionName,
+		Distance:           "Cosine",
+		VectorDimension:    3,
+		PayloadTableSchema: schema,
+	})
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+
+	_, err = c.Insert(ctx, &pb.InsertRequest{
+		CollectionName: collectionName,
+		Id:             1,
+		Vector:         []float32{1.0, 0.0, 0.0},
+	})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	// Wait for the insert to replicate before deleting, so a slow follower
+	// can't make the delete look replicated when it actually just never
+	// saw the insert.
+	time.Sleep(2 * time.Second)
+
+	deleteResp, err := c.Delete(ctx, &pb.DeleteRequest{
+		CollectionName: collectionName,
+		Id:             1,
+	})
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if !deleteResp.Success {
+		t.Fatalf("Delete not successful: %s", deleteResp.Message)
+	}
+
+	// Wait for the delete to replicate.
+	time.Sleep(2 * time.Second)
+
+	nodeAddrs := []string{node1ClusterAddr, node2ClusterAddr, node3ClusterAddr}
+	for _, addr := range nodeAddrs {
+		t.Run(fmt.Sprintf("Search_from_%s", addr), func(t *testing.T) {
+			nodeClient, err := client.NewClusterClientSimple(addr)
+			if err != nil {
+				t.Logf("Failed to connect to %s (might be down): %v", addr, err)
+				return
+			}
+			defer nodeClient.Close()
+
+			searchResp, err := nodeClient.Search(ctx, &pb.SearchRequest{
+				CollectionName: collectionName,
+				Vector:         []float32{1.0, 0.0, 0.0},
+				TopK:           5,
+			})
+			if err != nil {
+				t.Errorf("Search from %s failed: %v", addr, err)
+				return
+			}
+
+			if len(searchResp.Results) != 0 {
+				t.Errorf("expected deleted point to be gone from %s, got %d results", addr, len(searchResp.Results))
+			}
+		})
+	}
+}
+
+// TestJoinCatchesUpViaSnapshot verifies that a node joining after the
+// leader has already compacted its log receives the cluster's state
+// solely through a Raft InstallSnapshot, not by replaying log entries it
+// was never a voter for.
+func TestJoinCatchesUpViaSnapshot(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	leaderClient, err := client.NewClusterClientSimple(node1ClusterAddr)
+	if err != nil {
+		t.Fatalf("Failed to connect to existing cluster: %v", err)
+	}
+	defer leaderClient.Close()
+
+	info, err := leaderClient.GetClusterInfo(ctx)
+	if err != nil {
+		t.Fatalf("GetClusterInfo failed: %v", err)
+	}
+	if len(info.Nodes) < 2 {
+		t.Skip("Need at least a 2-node cluster already running")
+	}
+
+	collectionName := fmt.Sprintf("snapshot_join_test_%d", time.Now().UnixNano())
+	schema := fmt.Sprintf("CREATE TABLE %s (rowid INTEGER PRIMARY KEY)", collectionName)
+
+	if _, err := leaderClient.CreateCollection(ctx, &pb.CreateCollectionRequest{
+		CollectionName:     collectionName,
+		Distance:           "Cosine",
+		VectorDimension:    3,
+		PayloadTableSchema: schema,
+	}); err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+
+	// Enough writes to push the existing nodes past their configured
+	// SnapshotThreshold and take at least one snapshot, compacting the
+	// log node4 would otherwise need to replay.
+	const numPoints = 500
+	for i := 0; i < numPoints; i++ {
+		id := int64(i + 1)
+		if _, err := leaderClient.Insert(ctx, &pb.InsertRequest{
+			CollectionName: collectionName,
+			Id:             id,
+			Vector:         []float32{float32(id), float32(id + 1), float32(id + 2)},
+		}); err != nil {
+			t.Fatalf("Insert id %d failed: %v", id, err)
+		}
+	}
+
+	// Give hashicorp/raft a moment to notice SnapshotThreshold was
+	// crossed and compact the log.
+	time.Sleep(3 * time.Second)
+
+	// node4 is assumed to already be running, started fresh with no
+	// prior log entries and not yet a member of the cluster.
+	if _, err := leaderClient.JoinCluster(ctx, "node4", node4RaftAddr, false, 1, nil); err != nil {
+		t.Fatalf("JoinCluster for node4 failed: %v", err)
+	}
+
+	// Give node4 time to receive and apply the InstallSnapshot RPC.
+	time.Sleep(5 * time.Second)
+
+	node4Client, err := client.NewClusterClientSimple(node4ClusterAddr)
+	if err != nil {
+		t.Fatalf("Failed to connect to node4: %v", err)
+	}
+	defer node4Client.Close()
+
+	searchResp, err := node4Client.Search(ctx, &pb.SearchRequest{
+		CollectionName: collectionName,
+		Vector:         []float32{1.0, 2.0, 3.0},
+		TopK:           numPoints,
+	})
+	if err != nil {
+		t.Fatalf("Search on node4 failed: %v", err)
+	}
+
+	if len(searchResp.Results) != numPoints {
+		t.Errorf("node4 should have received all %d points via snapshot, got %d", numPoints, len(searchResp.Results))
+	}
+}
+
+// TestBulkInsert exercises the streaming BulkInsert RPC end to end.
+// BulkInsert is PolicyLeader, so ClusterClient opens the stream on the
+// endpoint it resolves as the leader; a follower would reject it in
+// LeaderRedirectInterceptor.Stream, and BulkInsert does not retry a
+// rejected stream.
+func TestBulkInsert(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	c, err := client.NewClusterClientSimple(node1ClusterAddr)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer c.Close()
+
+	collectionName := fmt.Sprintf("cluster_test_bulk_%d", time.Now().UnixNano())
+	schema := fmt.Sprintf("CREATE TABLE %s (rowid INTEGER PRIMARY KEY, name TEXT)", collectionName)
+
+	createResp, err := c.CreateCollection(ctx, &pb.CreateCollectionRequest{
+		CollectionName:     collectionName,
+		Distance:           "Cosine",
+		VectorDimension:    3,
+		PayloadTableSchema: schema,
+	})
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if !createResp.Success {
+		t.Fatalf("CreateCollection not successful: %s", createResp.Message)
+	}
+
+	const numPoints = 50
+	reqs := make([]*pb.InsertRequest, numPoints)
+	for i := 0; i < numPoints; i++ {
+		reqs[i] = &pb.InsertRequest{
+			CollectionName:     collectionName,
+			Id:                 int64(i + 1),
+			Vector:             []float32{float32(i), float32(i + 1), float32(i + 2)},
+			PayloadInsertQuery: fmt.Sprintf("INSERT INTO %s (name) VALUES ('row-%d')", collectionName, i),
+		}
+	}
+
+	bulkResp, err := c.BulkInsert(ctx, reqs)
+	if err != nil {
+		t.Fatalf("BulkInsert failed: %v", err)
+	}
+	if bulkResp.Inserted != numPoints || bulkResp.Failed != 0 {
+		t.Fatalf("expected %d inserted/0 failed, got %d inserted/%d failed", numPoints, bulkResp.Inserted, bulkResp.Failed)
+	}
+
+	searchResp, err := c.Search(ctx, &pb.SearchRequest{
+		CollectionName: collectionName,
+		Vector:         []float32{0, 1, 2},
+		TopK:           numPoints,
+	})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(searchResp.Results) != numPoints {
+		t.Errorf("expected %d points visible after BulkInsert, got %d", numPoints, len(searchResp.Results))
+	}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	return len(s) > 0 && len(substr) > 0 && (s == substr || len(s) >= len(substr) && s[len(s)-len(substr):] == substr)
@@ -0,0 +1,67 @@
+package types
+
+// BatchOptions configures how BatchInsert dispatches a slice of points.
+type BatchOptions struct {
+	// MaxConcurrent bounds the number of in-flight Insert RPCs (default: 20).
+	MaxConcurrent int
+	// BatchSize, when > 0, groups points into server-side batches of this
+	// size sent over the streaming Insert RPC instead of one RPC per point.
+	BatchSize int
+}
+
+// BatchInsertBuilder builds a BatchOptions with sane defaults.
+type BatchInsertBuilder struct {
+	opts BatchOptions
+}
+
+// NewBatchInsertBuilder creates a builder pre-populated with defaults.
+func NewBatchInsertBuilder() *BatchInsertBuilder {
+	return &BatchInsertBuilder{
+		opts: BatchOptions{
+			MaxConcurrent: 20,
+		},
+	}
+}
+
+func (b *BatchInsertBuilder) MaxConcurrent(n int) *BatchInsertBuilder {
+	b.opts.MaxConcurrent = n
+	return b
+}
+
+func (b *BatchInsertBuilder) BatchSize(n int) *BatchInsertBuilder {
+	b.opts.BatchSize = n
+	return b
+}
+
+func (b *BatchInsertBuilder) Build() BatchOptions {
+	if b.opts.MaxConcurrent <= 0 {
+		b.opts.MaxConcurrent = 20
+	}
+	return b.opts
+}
+
+// BatchFailure records the original index of a point that failed to insert.
+type BatchFailure struct {
+	Index int
+	Err   error
+}
+
+// BatchResult reports the outcome of a BatchInsert call.
+type BatchResult struct {
+	Successes []int
+	Failures  []BatchFailure
+	FirstErr  error
+}
+
+// AddSuccess records a successful insert at the given original index.
+func (r *BatchResult) AddSuccess(index int) {
+	r.Successes = append(r.Successes, index)
+}
+
+// AddFailure records a failed insert at the given original index.
+func (r *BatchResult) AddFailure(index int, err error) {
+	r.Failures = append(r.Failures, BatchFailure{Index: index, Err: err})
+	if r.FirstErr == nil {
+		r.FirstErr = err
+	}
+}
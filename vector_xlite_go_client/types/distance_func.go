@@ -7,6 +7,9 @@ const (
 	DistanceCosine
 	DistanceEuclidean
 	DistanceDot
+	DistanceManhattan
+	DistanceHamming
+	DistanceJaccard
 )
 
 func (d DistanceFunction) String() string {
@@ -17,7 +20,36 @@ func (d DistanceFunction) String() string {
 		return "Euclidean"
 	case DistanceDot:
 		return "Dot"
+	case DistanceManhattan:
+		return "Manhattan"
+	case DistanceHamming:
+		return "Hamming"
+	case DistanceJaccard:
+		return "Jaccard"
 	default:
 		return "Unknown"
 	}
 }
+
+// VectorEncoding selects the on-disk representation for a collection's
+// vectors. Hamming and Jaccard distances operate on packed bits rather than
+// float32 components, so collections using them should pick Binary or
+// UInt8 to avoid storing a wasteful float32 array.
+type VectorEncoding int
+
+const (
+	VectorEncodingFloat32 VectorEncoding = iota
+	VectorEncodingBinary
+	VectorEncodingUInt8
+)
+
+func (e VectorEncoding) String() string {
+	switch e {
+	case VectorEncodingBinary:
+		return "Binary"
+	case VectorEncodingUInt8:
+		return "UInt8"
+	default:
+		return "Float32"
+	}
+}
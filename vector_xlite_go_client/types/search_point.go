@@ -2,11 +2,41 @@ package types
 
 import "errors"
 
+// SearchStrategy selects how Filter is combined with ANN traversal.
+type SearchStrategy int
+
+const (
+	// StrategyPostFilter over-fetches TopK*FilterMultiplier candidates by
+	// ANN distance, then drops any that don't match Filter.
+	StrategyPostFilter SearchStrategy = iota
+	// StrategyPreFilter runs Filter against the payload table first to get
+	// a candidate rowid set, then restricts ANN traversal to it.
+	StrategyPreFilter
+)
+
+func (s SearchStrategy) String() string {
+	switch s {
+	case StrategyPreFilter:
+		return "PreFilter"
+	default:
+		return "PostFilter"
+	}
+}
+
 type SearchPoint struct {
 	CollectionName     string
 	Vector             []float32
 	TopK               uint32
 	PayloadSearchQuery string // optional SQL to fetch/filter payload
+	// Filter is a SQL WHERE-clause predicate over the payload table,
+	// evaluated according to Strategy.
+	Filter string
+	// Strategy selects how Filter is combined with ANN traversal. Defaults
+	// to StrategyPostFilter.
+	Strategy SearchStrategy
+	// FilterMultiplier controls how many extra candidates StrategyPostFilter
+	// over-fetches, as a multiple of TopK. Defaults to 3 when Filter is set.
+	FilterMultiplier uint32
 }
 
 type SearchPointBuilder struct {
@@ -31,6 +61,26 @@ func (b *SearchPointBuilder) PayloadSearchQuery(q string) *SearchPointBuilder {
 	b.s.PayloadSearchQuery = q
 	return b
 }
+
+// Filter sets a SQL WHERE-clause predicate to prune candidates before or
+// after ANN scoring, depending on Strategy.
+func (b *SearchPointBuilder) Filter(expr string) *SearchPointBuilder {
+	b.s.Filter = expr
+	return b
+}
+
+// Strategy selects how Filter is combined with ANN traversal.
+func (b *SearchPointBuilder) Strategy(s SearchStrategy) *SearchPointBuilder {
+	b.s.Strategy = s
+	return b
+}
+
+// FilterMultiplier sets the over-fetch multiple used by StrategyPostFilter.
+func (b *SearchPointBuilder) FilterMultiplier(m uint32) *SearchPointBuilder {
+	b.s.FilterMultiplier = m
+	return b
+}
+
 func (b *SearchPointBuilder) Build() (*SearchPoint, error) {
 	if b.s.CollectionName == "" {
 		return nil, errors.New("collection_name required")
@@ -41,5 +91,8 @@ func (b *SearchPointBuilder) Build() (*SearchPoint, error) {
 	if b.s.TopK == 0 {
 		b.s.TopK = 10
 	}
+	if b.s.Filter != "" && b.s.FilterMultiplier == 0 {
+		b.s.FilterMultiplier = 3
+	}
 	return &b.s, nil
 }
@@ -1,6 +1,12 @@
 package types
 
-import "errors"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+)
 
 // SnapshotFileType represents the type of file in a snapshot
 type SnapshotFileType int
@@ -32,6 +38,10 @@ type ExportSnapshotRequest struct {
 	ChunkSize uint32
 	// IncludeIndexFiles specifies whether to include HNSW index files
 	IncludeIndexFiles bool
+	// ResumeFrom maps file name to the last chunk offset the caller already
+	// has, so a reconnecting follower can resume a partial transfer instead
+	// of re-streaming files from the start. Nil means export everything.
+	ResumeFrom map[string]uint64
 }
 
 // ExportSnapshotRequestBuilder builds an ExportSnapshotRequest
@@ -61,12 +71,23 @@ func (b *ExportSnapshotRequestBuilder) IncludeIndexFiles(include bool) *ExportSn
 	return b
 }
 
+// ResumeFrom sets the per-file offsets the caller already has, so the
+// export only streams the remainder of each file.
+func (b *ExportSnapshotRequestBuilder) ResumeFrom(offsets map[string]uint64) *ExportSnapshotRequestBuilder {
+	b.req.ResumeFrom = offsets
+	return b
+}
+
 // Build returns the built request
 func (b *ExportSnapshotRequestBuilder) Build() *ExportSnapshotRequest {
 	return &b.req
 }
 
-// SnapshotFileInfo contains information about a file in the snapshot
+// SnapshotFileInfo contains information about a file in the snapshot. It
+// doubles as the snapshot's manifest entry: Checksum is the file's SHA256,
+// so a completed import can be validated end-to-end against the metadata
+// that was sent in the first chunk, independent of the per-chunk CRC32C
+// checks done in transit.
 type SnapshotFileInfo struct {
 	FileName string
 	FileType SnapshotFileType
@@ -90,6 +111,9 @@ type FileChunk struct {
 	Offset      uint64
 	Data        []byte
 	IsLastChunk bool
+	// Checksum is the hex-encoded CRC32C (Castagnoli) of Data, used to
+	// detect corruption introduced by a dropped/retried stream.
+	Checksum string
 }
 
 // SnapshotChunk represents a chunk of snapshot data
@@ -100,6 +124,16 @@ type SnapshotChunk struct {
 	IsFinal   bool
 }
 
+// ImportFromLeaderRequest describes a snapshot pull directly from a Raft
+// leader, used by a follower bootstrapping or catching up.
+type ImportFromLeaderRequest struct {
+	// LeaderAddr is the leader's gRPC address (host:port).
+	LeaderAddr string
+	// ResumeToken maps file name to the last chunk offset this node has
+	// already applied. Nil starts the import from scratch.
+	ResumeToken map[string]uint64
+}
+
 // ImportSnapshotResponse represents the response from importing a snapshot
 type ImportSnapshotResponse struct {
 	Success       bool
@@ -192,3 +226,67 @@ func (c *SnapshotCollector) IsComplete() bool {
 	lastChunk := c.Chunks[len(c.Chunks)-1]
 	return lastChunk.IsFinal
 }
+
+// ComputeChecksum returns a SHA-256 digest over the snapshot's per-file
+// manifest entries (name and checksum, in file order), for comparing
+// snapshots taken on different followers without re-hashing their data.
+func (c *SnapshotCollector) ComputeChecksum() string {
+	if c.Metadata == nil {
+		return ""
+	}
+	h := sha256.New()
+	for _, f := range c.Metadata.Files {
+		h.Write([]byte(f.FileName))
+		h.Write([]byte(f.Checksum))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ErrFileChecksumMismatch is returned by VerifyFileChecksums when a file's
+// chunks don't hash to the checksum recorded for it in the manifest.
+type ErrFileChecksumMismatch struct {
+	FileName string
+	Want     string
+	Got      string
+}
+
+func (e *ErrFileChecksumMismatch) Error() string {
+	return fmt.Sprintf("snapshot file %q checksum mismatch: want %s, got %s", e.FileName, e.Want, e.Got)
+}
+
+// VerifyFileChecksums recomputes each file's SHA-256 from its chunks'
+// data, in the order they were collected, and compares it against that
+// file's manifest entry in Metadata.Files. It returns an
+// ErrFileChecksumMismatch for the first file that doesn't match, so a
+// restore can be rejected before its chunks are handed to ImportSnapshot.
+func (c *SnapshotCollector) VerifyFileChecksums() error {
+	if c.Metadata == nil {
+		return nil
+	}
+
+	hashes := make(map[string]hash.Hash, len(c.Metadata.Files))
+	for _, chunk := range c.Chunks {
+		fc := chunk.FileChunk
+		if fc == nil {
+			continue
+		}
+		h, ok := hashes[fc.FileName]
+		if !ok {
+			h = sha256.New()
+			hashes[fc.FileName] = h
+		}
+		h.Write(fc.Data)
+	}
+
+	for _, f := range c.Metadata.Files {
+		h, ok := hashes[f.FileName]
+		if !ok || f.Checksum == "" {
+			continue
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != f.Checksum {
+			return &ErrFileChecksumMismatch{FileName: f.FileName, Want: f.Checksum, Got: got}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package consensus
+
+import "sync"
+
+// CurrentProtocolVersion is this binary's own capability protocol version,
+// bumped whenever a new gated Command.Type is introduced. A node advertises
+// it when joining (see JoinCluster) so the leader can refuse a version it
+// doesn't know how to talk to.
+const CurrentProtocolVersion = 1
+
+// MinSupportedProtocolVersion and MaxSupportedProtocolVersion bound the
+// ProtocolVersion this binary accepts from a joining node, so a leader
+// rejects a newcomer running either an ancient, unmaintained build or a
+// future one with protocol changes this build predates.
+const (
+	MinSupportedProtocolVersion = 1
+	MaxSupportedProtocolVersion = 1
+)
+
+// commandFeatures maps a CommandType introduced after the capability system
+// existed to the feature flag that must be in the cluster-wide minimum
+// before the leader may propose it. A CommandType absent from this map
+// predates capability gating and is never refused.
+var commandFeatures = map[CommandType]string{
+	CmdBulkInsert: "bulk_insert",
+}
+
+// RequiredFeature returns the feature flag gating t, and whether t is gated
+// at all.
+func RequiredFeature(t CommandType) (feature string, gated bool) {
+	feature, gated = commandFeatures[t]
+	return feature, gated
+}
+
+// ClusterCapabilities is one node's advertised protocol version and feature
+// set, gossiped to the leader during JoinCluster and replicated into every
+// FSM via CmdRegisterCapabilities so all members agree on it.
+type ClusterCapabilities struct {
+	ProtocolVersion int             `json:"protocolVersion"`
+	Features        map[string]bool `json:"features"`
+}
+
+// CurrentCapabilities returns the capability set this binary advertises
+// when joining a cluster: its own ProtocolVersion, and every feature flag
+// it knows how to apply.
+func CurrentCapabilities() ClusterCapabilities {
+	features := make(map[string]bool, len(commandFeatures))
+	for _, feature := range commandFeatures {
+		features[feature] = true
+	}
+	return ClusterCapabilities{
+		ProtocolVersion: CurrentProtocolVersion,
+		Features:        features,
+	}
+}
+
+// capabilitiesTable is the FSM-replicated store of every cluster member's
+// last-advertised ClusterCapabilities, keyed by node ID.
+type capabilitiesTable struct {
+	mu   sync.RWMutex
+	byID map[string]ClusterCapabilities
+}
+
+func newCapabilitiesTable() *capabilitiesTable {
+	return &capabilitiesTable{byID: make(map[string]ClusterCapabilities)}
+}
+
+func (t *capabilitiesTable) set(nodeID string, caps ClusterCapabilities) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.byID[nodeID] = caps
+}
+
+// snapshot returns a copy of every member's capabilities, safe for a caller
+// to range over without holding the table's lock.
+func (t *capabilitiesTable) snapshot() map[string]ClusterCapabilities {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make(map[string]ClusterCapabilities, len(t.byID))
+	for id, caps := range t.byID {
+		out[id] = caps
+	}
+	return out
+}
+
+// MinClusterCapability returns the intersection of every member's
+// capabilities known to fsm: the lowest advertised ProtocolVersion, and a
+// feature set containing only flags every member has set. An empty table
+// (nothing advertised yet, e.g. a freshly bootstrapped single node that
+// hasn't round-tripped through JoinCluster) returns CurrentCapabilities, so
+// a solo node isn't gated against itself.
+func MinClusterCapability(fsm *VxFSM) ClusterCapabilities {
+	members := fsm.capabilities.snapshot()
+	if len(members) == 0 {
+		return CurrentCapabilities()
+	}
+
+	var min ClusterCapabilities
+	first := true
+	for _, caps := range members {
+		if first {
+			min = ClusterCapabilities{
+				ProtocolVersion: caps.ProtocolVersion,
+				Features:        intersectFeatures(nil, caps.Features),
+			}
+			first = false
+			continue
+		}
+		if caps.ProtocolVersion < min.ProtocolVersion {
+			min.ProtocolVersion = caps.ProtocolVersion
+		}
+		min.Features = intersectFeatures(min.Features, caps.Features)
+	}
+	return min
+}
+
+// intersectFeatures returns the features set true in both a and b. A nil a
+// is treated as "everything in b", so the first member in a MinClusterCapability
+// fold seeds the set instead of intersecting against nothing.
+func intersectFeatures(a, b map[string]bool) map[string]bool {
+	if a == nil {
+		out := make(map[string]bool, len(b))
+		for k, v := range b {
+			out[k] = v
+		}
+		return out
+	}
+	out := make(map[string]bool, len(a))
+	for k, v := range a {
+		if v && b[k] {
+			out[k] = true
+		}
+	}
+	return out
+}
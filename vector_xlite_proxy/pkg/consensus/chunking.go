@@ -0,0 +1,112 @@
+package consensus
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+// defaultMaxCommandBytes is the serialized Command size above which
+// ChunkingApplier splits it into CmdChunk fragments instead of proposing it
+// directly, keeping any single Raft log entry well under the sizes that
+// make hashicorp/raft's AppendEntries replication stall.
+const defaultMaxCommandBytes = 512 * 1024
+
+// ChunkingApplier wraps a VxRaftNode so that any Command whose serialized
+// size exceeds MaxCommandBytes is transparently split into ordered
+// CmdChunk entries instead of being proposed as one oversized Raft log
+// entry, the approach go-raftchunking uses for the same problem.
+type ChunkingApplier struct {
+	raftNode        *VxRaftNode
+	maxCommandBytes int
+}
+
+// NewChunkingApplier creates a ChunkingApplier over raftNode. maxCommandBytes
+// <= 0 uses defaultMaxCommandBytes.
+func NewChunkingApplier(raftNode *VxRaftNode, maxCommandBytes int) *ChunkingApplier {
+	if maxCommandBytes <= 0 {
+		maxCommandBytes = defaultMaxCommandBytes
+	}
+	return &ChunkingApplier{raftNode: raftNode, maxCommandBytes: maxCommandBytes}
+}
+
+// Apply marshals cmd and proposes it to Raft, transparently splitting it
+// into CmdChunk fragments first if it's larger than MaxCommandBytes.
+// Fragments are applied one at a time, waiting for each to commit before
+// sending the next so the FSM reassembles them in order; the returned
+// future is whichever Apply call carries the final outcome (the only
+// proposal for a small command, or the last fragment for a chunked one,
+// since VxFSM.applyChunk only executes and returns the real ApplyResult
+// once the last fragment lands).
+func (a *ChunkingApplier) Apply(cmd Command, timeout time.Duration) (raft.ApplyFuture, error) {
+	if feature, gated := RequiredFeature(cmd.Type); gated {
+		if min := MinClusterCapability(a.raftNode.Fsm); !min.Features[feature] {
+			return nil, fmt.Errorf("command type %s requires feature %q, not yet supported cluster-wide (finish upgrading all nodes first)", cmd.Type, feature)
+		}
+	}
+
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("marshal command: %w", err)
+	}
+
+	if len(data) <= a.maxCommandBytes {
+		return a.raftNode.Apply(data, timeout), nil
+	}
+
+	chunkID, err := newChunkID()
+	if err != nil {
+		return nil, fmt.Errorf("generate chunk id: %w", err)
+	}
+
+	total := (len(data) + a.maxCommandBytes - 1) / a.maxCommandBytes
+	var last raft.ApplyFuture
+	for seq := 0; seq < total; seq++ {
+		start := seq * a.maxCommandBytes
+		end := start + a.maxCommandBytes
+		if end > len(data) {
+			end = len(data)
+		}
+
+		fragPayload, err := json.Marshal(ChunkPayload{
+			ChunkID: chunkID,
+			Seq:     seq,
+			Total:   total,
+			Data:    data[start:end],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("marshal chunk %d/%d: %w", seq+1, total, err)
+		}
+
+		fragCmd, err := json.Marshal(Command{Type: CmdChunk, Payload: fragPayload})
+		if err != nil {
+			return nil, fmt.Errorf("marshal chunk envelope %d/%d: %w", seq+1, total, err)
+		}
+
+		future := a.raftNode.Apply(fragCmd, timeout)
+		if seq < total-1 {
+			// Fragments must land in order, since applyChunk reassembles by
+			// concatenating received parts in sequence order.
+			if err := future.Error(); err != nil {
+				return nil, fmt.Errorf("apply chunk %d/%d: %w", seq+1, total, err)
+			}
+		}
+		last = future
+	}
+
+	return last, nil
+}
+
+// newChunkID returns a random identifier grouping one command's fragments,
+// unique enough that concurrent chunked Applies never collide.
+func newChunkID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,122 @@
+package consensus
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NodeInfo holds minimal details for a node used during bootstrap.
+type NodeInfo struct {
+	ID   string
+	Addr string
+}
+
+type CommandType int
+
+const (
+	CmdCreateCollection CommandType = iota + 1
+	CmdInsert
+	CmdDelete
+	// CmdInsertBatch carries many points as a single Raft log entry, so
+	// bulk ingestion pays one consensus round-trip instead of one per
+	// point.
+	CmdInsertBatch
+	// CmdChunk carries one fragment of a Command too large to fit in a
+	// single Raft entry; see ChunkingApplier.
+	CmdChunk
+	// CmdBulkInsert carries one coalesced batch of points from a streaming
+	// BulkInsert RPC. Its payload has the same shape as CmdInsertBatch's
+	// (a JSON array of types.InsertPoint), so applyCommand dispatches both
+	// to applyInsertBatch; the distinct type exists so Apply latency
+	// metrics and logging can tell the two ingestion paths apart.
+	CmdBulkInsert
+	// CmdRegisterCapabilities carries one member's advertised
+	// ClusterCapabilities, gossiped during JoinCluster and applied to
+	// every FSM so MinClusterCapability agrees cluster-wide on what's
+	// safe to propose. Never gated behind RequiredFeature itself, since a
+	// node that can't apply this command couldn't learn any capabilities
+	// at all.
+	CmdRegisterCapabilities
+	// CmdDeleteBatch carries many deletes as a single Raft log entry, the
+	// delete-side counterpart to CmdInsertBatch.
+	CmdDeleteBatch
+)
+
+// String renders a CommandType for logging and metrics labels (e.g.
+// Collectors.ObserveApply), falling back to its numeric value for any
+// type added here without a case below.
+func (t CommandType) String() string {
+	switch t {
+	case CmdCreateCollection:
+		return "CreateCollection"
+	case CmdInsert:
+		return "Insert"
+	case CmdDelete:
+		return "Delete"
+	case CmdInsertBatch:
+		return "InsertBatch"
+	case CmdChunk:
+		return "Chunk"
+	case CmdBulkInsert:
+		return "BulkInsert"
+	case CmdRegisterCapabilities:
+		return "RegisterCapabilities"
+	case CmdDeleteBatch:
+		return "DeleteBatch"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(t))
+	}
+}
+
+// Command is the envelope applied to the Raft log.
+type Command struct {
+	Type    CommandType     `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// DeletePayload is the Raft command payload for a single-row delete.
+type DeletePayload struct {
+	CollectionName string `json:"collection_name"`
+	ID             int64  `json:"id"`
+}
+
+// ApplyResult is returned from VxFSM.Apply.
+type ApplyResult struct {
+	Data  interface{} `json:"data,omitempty"`
+	Error error       `json:"error,omitempty"`
+}
+
+// InsertBatchItemResult reports one point's outcome within a
+// CmdInsertBatch Apply, so a partial failure doesn't fail the entries
+// around it.
+type InsertBatchItemResult struct {
+	Index int   `json:"index"`
+	Error error `json:"error,omitempty"`
+}
+
+// DeleteBatchItemResult reports one delete's outcome within a
+// CmdDeleteBatch Apply, the delete-side counterpart to
+// InsertBatchItemResult.
+type DeleteBatchItemResult struct {
+	Index int   `json:"index"`
+	Error error `json:"error,omitempty"`
+}
+
+// RegisterCapabilitiesPayload is the Raft command payload for
+// CmdRegisterCapabilities: one member's node ID and the ClusterCapabilities
+// it advertised when joining.
+type RegisterCapabilitiesPayload struct {
+	NodeID       string              `json:"node_id"`
+	Capabilities ClusterCapabilities `json:"capabilities"`
+}
+
+// ChunkPayload is the Raft command payload for one fragment of an
+// oversized Command, as split and reassembled by ChunkingApplier and
+// VxFSM.applyChunk. ChunkID groups fragments belonging to the same
+// original command; Seq is this fragment's 0-based position among Total.
+type ChunkPayload struct {
+	ChunkID string `json:"chunk_id"`
+	Seq     int    `json:"seq"`
+	Total   int    `json:"total"`
+	Data    []byte `json:"data"`
+}
@@ -1,20 +1,90 @@
 package consensus
 
 import (
+	"bufio"
 	"context"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"log"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/raft"
 	client "github.com/uttom-akash/vector-xlite/go_grpc_client/client"
 	types "github.com/uttom-akash/vector-xlite/go_grpc_client/types"
+	"github.com/uttom-akash/vector-xlite/vector_xlite_proxy/pkg/metrics"
+	"github.com/uttom-akash/vector-xlite/vector_xlite_proxy/pkg/snapshots"
 )
 
+// ErrChunkChecksumMismatch is returned by decodeChunk/readLegacyChunk when
+// a chunk's CRC32C doesn't match what was read off the wire, indicating a
+// corrupted Raft snapshot stream.
+var ErrChunkChecksumMismatch = errors.New("consensus: snapshot chunk checksum mismatch")
+
+var chunkCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
 type VxFSM struct {
 	VectorClient *client.Client // Exported for external access
+
+	// SnapshotManager, if set, gives Snapshot/Restore a durable,
+	// multi-snapshot lifecycle instead of each Snapshot call only living in
+	// the raft.FSMSnapshot handed back to hashicorp/raft. Nil disables it,
+	// falling back to the old export-on-demand behavior.
+	SnapshotManager *snapshots.Manager
+
+	// Metrics, if set, gets Apply latency (by Command.Type) and snapshot
+	// install duration/bytes reported to it. Nil disables instrumentation.
+	Metrics *metrics.Collectors
+
+	lastApplied uint64 // atomic; the Raft log index of the last Apply, used as snapshot height
+
+	// ReadIndexWaiter, if set, is signaled with every Apply'd index, so a
+	// Linearizable Search can block on it directly instead of polling
+	// LastApplied. Nil falls back to that polling behavior (see
+	// ClusterServer.waitForApplied).
+	ReadIndexWaiter *ReadIndexWaiter
+
+	// WatchHub, if set, is published to with a WatchEvent for every
+	// applied CmdCreateCollection/CmdInsert/CmdDelete, for a Watch RPC
+	// subscriber to fan out from. Nil disables Watch entirely.
+	WatchHub *WatchHub
+
+	// OnApplied, if set, is called with the current time on every Apply,
+	// so a caller outside this package can track how long it has been since
+	// the last apply (see server.ClusterServer.RecordApplied) without this package
+	// needing to import it. Nil disables lag tracking entirely.
+	OnApplied func(now time.Time)
+
+	chunkMu       sync.Mutex
+	pendingChunks map[string]*chunkBuffer // keyed by ChunkPayload.ChunkID, for in-flight CmdChunk reassembly
+
+	// capabilities holds the last-advertised ClusterCapabilities of every
+	// member, applied via CmdRegisterCapabilities. Not included in
+	// Snapshot/Restore: a node rebuilds it by replaying CmdRegisterCapabilities
+	// entries still in the log, or by re-advertising on its next JoinCluster
+	// retry, so a snapshot-installing follower briefly treats the table as
+	// empty (MinClusterCapability then reports CurrentCapabilities) rather
+	// than gating on stale data.
+	capabilities *capabilitiesTable
+}
+
+// chunkBuffer accumulates the fragments of one oversized command being
+// reassembled by applyChunk, in the order ChunkingApplier sent them.
+type chunkBuffer struct {
+	Total    int
+	Received int
+	Parts    [][]byte
+}
+
+// LastApplied returns the Raft log index of the last entry this FSM has
+// applied, so a caller waiting on a ReadIndex can tell when it's caught up.
+func (f *VxFSM) LastApplied() uint64 {
+	return atomic.LoadUint64(&f.lastApplied)
 }
 
 // Apply applies a Raft log entry to the VectorXLite database
@@ -25,18 +95,167 @@ func (f *VxFSM) Apply(rlog *raft.Log) interface{} {
 		return &ApplyResult{Error: err}
 	}
 
-	ctx := context.Background()
+	atomic.StoreUint64(&f.lastApplied, rlog.Index)
+	if f.ReadIndexWaiter != nil {
+		f.ReadIndexWaiter.Signal(rlog.Index)
+	}
+	if f.OnApplied != nil {
+		f.OnApplied(time.Now())
+	}
+
+	if f.Metrics != nil {
+		start := time.Now()
+		defer func() {
+			f.Metrics.ObserveApply(cmd.Type.String(), time.Since(start))
+		}()
+	}
+
+	result := f.applyCommand(context.Background(), cmd)
+
+	if f.WatchHub != nil {
+		f.publishWatchEvent(rlog.Index, cmd)
+	}
+
+	return result
+}
+
+// publishWatchEvent extracts a WatchEvent from cmd for WatchHub
+// subscribers, for the command types Watch cares about. Unlike
+// applyCommand's dispatch, a decode failure here is non-fatal: the
+// command was already applied above, so a malformed payload just costs
+// watchers this one event rather than failing the whole Apply.
+func (f *VxFSM) publishWatchEvent(index uint64, cmd Command) {
+	switch cmd.Type {
+	case CmdCreateCollection:
+		var collectionConfig types.CollectionConfig
+		if err := json.Unmarshal(cmd.Payload, &collectionConfig); err == nil {
+			f.WatchHub.Publish(WatchEvent{Index: index, Type: cmd.Type, CollectionName: collectionConfig.CollectionName})
+		}
+	case CmdInsert:
+		var insertPoint types.InsertPoint
+		if err := json.Unmarshal(cmd.Payload, &insertPoint); err == nil {
+			f.WatchHub.Publish(WatchEvent{
+				Index:          index,
+				Type:           cmd.Type,
+				CollectionName: insertPoint.CollectionName,
+				PointID:        uint64(insertPoint.Id),
+				Vector:         insertPoint.Vector,
+			})
+		}
+	case CmdInsertBatch, CmdBulkInsert:
+		var points []types.InsertPoint
+		if err := json.Unmarshal(cmd.Payload, &points); err == nil {
+			for _, p := range points {
+				f.WatchHub.Publish(WatchEvent{
+					Index:          index,
+					Type:           cmd.Type,
+					CollectionName: p.CollectionName,
+					PointID:        uint64(p.Id),
+					Vector:         p.Vector,
+				})
+			}
+		}
+	case CmdDelete:
+		var del DeletePayload
+		if err := json.Unmarshal(cmd.Payload, &del); err == nil {
+			f.WatchHub.Publish(WatchEvent{
+				Index:          index,
+				Type:           cmd.Type,
+				CollectionName: del.CollectionName,
+				PointID:        uint64(del.ID),
+			})
+		}
+	case CmdDeleteBatch:
+		var deletes []DeletePayload
+		if err := json.Unmarshal(cmd.Payload, &deletes); err == nil {
+			for _, del := range deletes {
+				f.WatchHub.Publish(WatchEvent{
+					Index:          index,
+					Type:           cmd.Type,
+					CollectionName: del.CollectionName,
+					PointID:        uint64(del.ID),
+				})
+			}
+		}
+	}
+}
+
+// applyCommand dispatches a single Command, whether it arrived directly in
+// a Raft log entry or was just reassembled from CmdChunk fragments by
+// applyChunk.
+func (f *VxFSM) applyCommand(ctx context.Context, cmd Command) interface{} {
 	switch cmd.Type {
 	case CmdCreateCollection:
 		return f.applyCreateCollection(ctx, cmd.Payload)
 	case CmdInsert:
 		return f.applyInsert(ctx, cmd.Payload)
+	case CmdInsertBatch:
+		return f.applyInsertBatch(ctx, cmd.Payload)
+	case CmdBulkInsert:
+		// Same payload shape as CmdInsertBatch (a JSON array of
+		// types.InsertPoint); see CmdBulkInsert's doc comment.
+		return f.applyInsertBatch(ctx, cmd.Payload)
+	case CmdDelete:
+		return f.applyDelete(ctx, cmd.Payload)
+	case CmdDeleteBatch:
+		return f.applyDeleteBatch(ctx, cmd.Payload)
+	case CmdChunk:
+		return f.applyChunk(ctx, cmd.Payload)
+	case CmdRegisterCapabilities:
+		return f.applyRegisterCapabilities(cmd.Payload)
 	default:
 		log.Default()
 		return &ApplyResult{Error: nil}
 	}
 }
 
+// applyChunk buffers one fragment of an oversized command. Once all Total
+// fragments for a ChunkID have arrived, it reassembles and dispatches the
+// original Command, the ChunkingApplier counterpart to Apply splitting it.
+// Fragments are buffered in-memory and also captured by Snapshot/Restore,
+// so an in-flight chunked command survives a leader change.
+func (f *VxFSM) applyChunk(ctx context.Context, payload json.RawMessage) interface{} {
+	var frag ChunkPayload
+	if err := json.Unmarshal(payload, &frag); err != nil {
+		log.Fatalf("failed to deserialize command chunk, err: %v", err)
+		return &ApplyResult{Error: err}
+	}
+
+	f.chunkMu.Lock()
+	if f.pendingChunks == nil {
+		f.pendingChunks = make(map[string]*chunkBuffer)
+	}
+	buf, ok := f.pendingChunks[frag.ChunkID]
+	if !ok {
+		buf = &chunkBuffer{Total: frag.Total, Parts: make([][]byte, frag.Total)}
+		f.pendingChunks[frag.ChunkID] = buf
+	}
+	if buf.Parts[frag.Seq] == nil {
+		buf.Parts[frag.Seq] = frag.Data
+		buf.Received++
+	}
+	complete := buf.Received == buf.Total
+	if complete {
+		delete(f.pendingChunks, frag.ChunkID)
+	}
+	f.chunkMu.Unlock()
+
+	if !complete {
+		return &ApplyResult{Data: fmt.Sprintf("buffered chunk %d/%d for %s", frag.Seq+1, frag.Total, frag.ChunkID)}
+	}
+
+	full := make([]byte, 0)
+	for _, part := range buf.Parts {
+		full = append(full, part...)
+	}
+
+	var inner Command
+	if err := json.Unmarshal(full, &inner); err != nil {
+		return &ApplyResult{Error: fmt.Errorf("reassemble chunked command %s: %w", frag.ChunkID, err)}
+	}
+	return f.applyCommand(ctx, inner)
+}
+
 func (f *VxFSM) applyCreateCollection(ctx context.Context, payload json.RawMessage) interface{} {
 
 	var collectionConfig types.CollectionConfig
@@ -66,7 +285,88 @@ func (f *VxFSM) applyInsert(ctx context.Context, payload json.RawMessage) interf
 	return &ApplyResult{}
 }
 
-// Snapshot returns an FSMSnapshot for Raft snapshot support
+// applyInsertBatch applies the many points proposed together as a single
+// CmdInsertBatch entry, the bulk-ingestion counterpart to applyInsert.
+// Each point's outcome is reported independently in the returned
+// ApplyResult.Data, so one bad point doesn't fail the whole batch.
+func (f *VxFSM) applyInsertBatch(ctx context.Context, payload json.RawMessage) interface{} {
+	var points []types.InsertPoint
+	if err := json.Unmarshal(payload, &points); err != nil {
+		log.Fatalf("failed to deserialize the insert batch, err: %v", err)
+		return &ApplyResult{Error: err}
+	}
+
+	pointPtrs := make([]*types.InsertPoint, len(points))
+	for i := range points {
+		pointPtrs[i] = &points[i]
+	}
+
+	itemErrs, err := f.VectorClient.InsertBatch(ctx, pointPtrs)
+	if err != nil {
+		return &ApplyResult{Error: err}
+	}
+
+	results := make([]InsertBatchItemResult, len(pointPtrs))
+	for i := range pointPtrs {
+		var itemErr error
+		if i < len(itemErrs) {
+			itemErr = itemErrs[i]
+		}
+		results[i] = InsertBatchItemResult{Index: i, Error: itemErr}
+	}
+
+	return &ApplyResult{Data: results}
+}
+
+func (f *VxFSM) applyDelete(ctx context.Context, payload json.RawMessage) interface{} {
+	var del DeletePayload
+
+	if err := json.Unmarshal(payload, &del); err != nil {
+		return &ApplyResult{Error: err}
+	}
+
+	_, err := f.VectorClient.Delete(ctx, del.CollectionName, del.ID)
+
+	return &ApplyResult{Error: err}
+}
+
+// applyDeleteBatch applies the many deletes proposed together as a single
+// CmdDeleteBatch entry, the delete-side counterpart to applyInsertBatch.
+// Each delete's outcome is reported independently in the returned
+// ApplyResult.Data, so one bad delete doesn't fail the whole batch.
+func (f *VxFSM) applyDeleteBatch(ctx context.Context, payload json.RawMessage) interface{} {
+	var deletes []DeletePayload
+	if err := json.Unmarshal(payload, &deletes); err != nil {
+		return &ApplyResult{Error: err}
+	}
+
+	results := make([]DeleteBatchItemResult, len(deletes))
+	for i, del := range deletes {
+		_, err := f.VectorClient.Delete(ctx, del.CollectionName, del.ID)
+		results[i] = DeleteBatchItemResult{Index: i, Error: err}
+	}
+
+	return &ApplyResult{Data: results}
+}
+
+// applyRegisterCapabilities records one member's advertised
+// ClusterCapabilities into this FSM's capabilities table, replicating the
+// gossip JoinCluster performs so every node's MinClusterCapability agrees.
+func (f *VxFSM) applyRegisterCapabilities(payload json.RawMessage) interface{} {
+	var reg RegisterCapabilitiesPayload
+	if err := json.Unmarshal(payload, &reg); err != nil {
+		return &ApplyResult{Error: err}
+	}
+
+	f.capabilities.set(reg.NodeID, reg.Capabilities)
+
+	return &ApplyResult{Data: reg}
+}
+
+// Snapshot returns an FSMSnapshot for Raft snapshot support. If
+// SnapshotManager is set, the export is also persisted there under the
+// current applied height, giving it a durable record independent of
+// whatever hashicorp/raft itself retains.
 func (f *VxFSM) Snapshot() (raft.FSMSnapshot, error) {
 	ctx := context.Background()
 
@@ -81,17 +381,144 @@ func (f *VxFSM) Snapshot() (raft.FSMSnapshot, error) {
 		return nil, err
 	}
 
+	if collector.Metadata != nil && collector.Metadata.Checksum == "" {
+		collector.Metadata.Checksum = collector.ComputeChecksum()
+	}
+
+	// Capture any in-flight CmdChunk reassembly as an extra pseudo-file in
+	// the same chunk stream, so a leader change mid-batch doesn't drop the
+	// fragments a new leader hasn't finished receiving yet.
+	if chunkState, err := f.pendingChunkStateChunk(); err != nil {
+		return nil, fmt.Errorf("capture pending chunk state: %w", err)
+	} else if chunkState != nil {
+		// readSnapshotChunks stops at the first IsFinal chunk, so the state
+		// chunk goes just before the export's final chunk, not after it.
+		if n := len(collector.Chunks); n > 0 && collector.Chunks[n-1].IsFinal {
+			final := collector.Chunks[n-1]
+			chunkState.Sequence = final.Sequence
+			final.Sequence++
+			collector.Chunks = append(collector.Chunks[:n-1], *chunkState, final)
+		} else {
+			chunkState.Sequence = uint64(len(collector.Chunks))
+			collector.Chunks = append(collector.Chunks, *chunkState)
+		}
+	}
+
+	if f.SnapshotManager != nil {
+		height := atomic.LoadUint64(&f.lastApplied)
+		if _, err := f.SnapshotManager.Create(height, collector); err != nil {
+			return nil, fmt.Errorf("persist snapshot to manager: %w", err)
+		}
+	}
+
 	return &VectorXLiteSnapshot{collector: collector}, nil
 }
 
-// Restore restores the FSM from a snapshot
+// pendingChunkStateFileName names the pseudo-file pendingChunkStateChunk
+// writes into the snapshot's chunk stream; restorePendingChunkState looks
+// for exactly this name.
+const pendingChunkStateFileName = "__raft_chunk_state__"
+
+// pendingChunkStateChunk serializes f.pendingChunks into a SnapshotChunk
+// carrying the special pendingChunkStateFileName, or returns nil if there's
+// nothing in flight (the common case, so most snapshots pay no extra
+// cost).
+func (f *VxFSM) pendingChunkStateChunk() (*types.SnapshotChunk, error) {
+	f.chunkMu.Lock()
+	defer f.chunkMu.Unlock()
+
+	if len(f.pendingChunks) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(f.pendingChunks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SnapshotChunk{
+		IsFinal: false,
+		FileChunk: &types.FileChunk{
+			FileName:    pendingChunkStateFileName,
+			Data:        data,
+			IsLastChunk: true,
+		},
+	}, nil
+}
+
+// restorePendingChunkState extracts the pendingChunkStateFileName chunk
+// from chunks, if present, restores f.pendingChunks from it, and returns
+// the remaining chunks with it removed so VectorClient.ImportSnapshot
+// never sees it.
+func (f *VxFSM) restorePendingChunkState(chunks []*types.SnapshotChunk) ([]*types.SnapshotChunk, error) {
+	remaining := make([]*types.SnapshotChunk, 0, len(chunks))
+	var stateData []byte
+	for _, chunk := range chunks {
+		if chunk.FileChunk != nil && chunk.FileChunk.FileName == pendingChunkStateFileName {
+			stateData = chunk.FileChunk.Data
+			continue
+		}
+		remaining = append(remaining, chunk)
+	}
+
+	f.chunkMu.Lock()
+	defer f.chunkMu.Unlock()
+	if len(stateData) == 0 {
+		f.pendingChunks = nil
+		return remaining, nil
+	}
+	var pending map[string]*chunkBuffer
+	if err := json.Unmarshal(stateData, &pending); err != nil {
+		return nil, fmt.Errorf("unmarshal pending chunk state: %w", err)
+	}
+	f.pendingChunks = pending
+	return remaining, nil
+}
+
+// Restore restores the FSM from a snapshot. If SnapshotManager is set, the
+// incoming snapshot's format tag is checked against it first, so an
+// unrecognized format is rejected with a typed error instead of failing
+// partway through ImportSnapshot.
 func (f *VxFSM) Restore(rc io.ReadCloser) error {
+	start := time.Now()
+
 	// Read all chunks from the snapshot reader
 	chunks, err := readSnapshotChunks(rc)
 	if err != nil {
 		return err
 	}
 
+	if f.Metrics != nil {
+		totalBytes := 0
+		for _, chunk := range chunks {
+			if chunk.FileChunk != nil {
+				totalBytes += len(chunk.FileChunk.Data)
+			}
+		}
+		defer func() {
+			f.Metrics.ObserveSnapshotInstall(time.Since(start), totalBytes)
+		}()
+	}
+
+	if f.SnapshotManager != nil {
+		if format, ok := snapshotFormat(chunks); ok && format != snapshots.CurrentFormat && !snapshots.HasConverter(format) {
+			return &snapshots.ErrUnknownFormat{Format: format}
+		}
+	}
+
+	chunks, err = f.restorePendingChunkState(chunks)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	collector := types.NewSnapshotCollector()
+	for _, chunk := range chunks {
+		collector.AddChunk(chunk)
+	}
+	if err := collector.VerifyFileChecksums(); err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
 	ctx := context.Background()
 
 	_, err = f.VectorClient.ImportSnapshot(ctx, chunks)
@@ -99,6 +526,17 @@ func (f *VxFSM) Restore(rc io.ReadCloser) error {
 	return err
 }
 
+// snapshotFormat returns the format tag carried by a chunk stream's
+// metadata, if any chunk has it set.
+func snapshotFormat(chunks []*types.SnapshotChunk) (snapshots.Format, bool) {
+	for _, chunk := range chunks {
+		if chunk.Metadata != nil {
+			return snapshots.Format(chunk.Metadata.Version), true
+		}
+	}
+	return 0, false
+}
+
 // VectorXLiteSnapshot implements raft.FSMSnapshot
 type VectorXLiteSnapshot struct {
 	collector *types.SnapshotCollector
@@ -116,45 +554,205 @@ func (s *VectorXLiteSnapshot) Persist(sink raft.SnapshotSink) error {
 
 func (s *VectorXLiteSnapshot) Release() {}
 
-// writeChunk writes a single snapshot chunk with length prefix to a writer.
-// Format: [4-byte length (uint32)][JSON-encoded chunk data]
+// Binary chunk format (see encodeChunk/decodeChunk). Replaces the
+// [length][crc32][JSON] framing used before this format, which
+// base64-inflated FileChunk.Data by ~33% over the wire and forced a full
+// JSON unmarshal per chunk. Header layout, all fields big-endian:
+//
+//	magic(4) | version(1) | seq(8) | flags(1) | metadataLen(4) |
+//	filenameLen(2) | offset(8) | dataLen(4) | crc32(4)
+//
+// followed by metadataLen bytes of JSON-encoded SnapshotMetadata (only
+// present on chunk 0), filenameLen bytes of the file name, then dataLen
+// raw file bytes. crc32 is the CRC32C of everything after the header.
+const (
+	chunkMagic        = "VXSC"
+	chunkFormatBinary = uint8(2)
+
+	chunkFlagIsFinal      uint8 = 1 << 0
+	chunkFlagIsLastChunk  uint8 = 1 << 1
+	chunkHeaderSize             = 4 + 1 + 8 + 1 + 4 + 2 + 8 + 4 + 4
+)
+
+// chunkPayloadPool reuses the scratch buffer decodeChunk reads a chunk's
+// metadata/filename/data payload into, so Restore doesn't allocate a new
+// buffer per chunk just to immediately discard it.
+var chunkPayloadPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 256*1024)
+		return &buf
+	},
+}
+
+// writeChunk writes a single snapshot chunk using the binary format
+// described above.
 func writeChunk(w io.Writer, chunk *types.SnapshotChunk) error {
-	// Marshal chunk to JSON
-	data, err := json.Marshal(chunk)
-	if err != nil {
-		return fmt.Errorf("failed to marshal chunk: %w", err)
-	}
+	return encodeChunk(w, chunk)
+}
 
-	// Write length prefix (4 bytes, big endian)
-	length := uint32(len(data))
-	if err := binary.Write(w, binary.BigEndian, length); err != nil {
-		return fmt.Errorf("failed to write length prefix: %w", err)
+// encodeChunk binary-encodes chunk to w. Unlike JSON, FileChunk.Data is
+// written as raw bytes, never base64-encoded or copied through a
+// marshaler.
+func encodeChunk(w io.Writer, chunk *types.SnapshotChunk) error {
+	var metadata []byte
+	if chunk.Metadata != nil {
+		var err error
+		metadata, err = json.Marshal(chunk.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal chunk metadata: %w", err)
+		}
 	}
 
-	// Write JSON data
-	if _, err := w.Write(data); err != nil {
-		return fmt.Errorf("failed to write chunk data: %w", err)
+	var filename string
+	var offset uint64
+	var data []byte
+	var flags uint8
+	if chunk.IsFinal {
+		flags |= chunkFlagIsFinal
+	}
+	if fc := chunk.FileChunk; fc != nil {
+		filename = fc.FileName
+		offset = fc.Offset
+		data = fc.Data
+		if fc.IsLastChunk {
+			flags |= chunkFlagIsLastChunk
+		}
 	}
 
+	payload := make([]byte, 0, len(metadata)+len(filename)+len(data))
+	payload = append(payload, metadata...)
+	payload = append(payload, filename...)
+	payload = append(payload, data...)
+	crc := crc32.Checksum(payload, chunkCRCTable)
+
+	header := make([]byte, chunkHeaderSize)
+	copy(header[0:4], chunkMagic)
+	header[4] = chunkFormatBinary
+	binary.BigEndian.PutUint64(header[5:13], chunk.Sequence)
+	header[13] = flags
+	binary.BigEndian.PutUint32(header[14:18], uint32(len(metadata)))
+	binary.BigEndian.PutUint16(header[18:20], uint16(len(filename)))
+	binary.BigEndian.PutUint64(header[20:28], offset)
+	binary.BigEndian.PutUint32(header[28:32], uint32(len(data)))
+	binary.BigEndian.PutUint32(header[32:36], crc)
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write chunk header: %w", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return fmt.Errorf("failed to write chunk payload: %w", err)
+	}
 	return nil
 }
 
-// readChunk reads a single snapshot chunk with length prefix from a reader.
-// Returns io.EOF when no more chunks are available.
-func readChunk(r io.Reader) (*types.SnapshotChunk, error) {
-	// Read length prefix (4 bytes, big endian)
+// decodeChunk binary-decodes a single chunk from r using a pooled scratch
+// buffer for its payload, so Restore doesn't allocate one per chunk.
+// Returns io.EOF when r is exhausted at a chunk boundary, or
+// ErrChunkChecksumMismatch when the payload has been corrupted. The sole
+// caller, readChunk, only calls this after Peek has confirmed the chunk's
+// magic bytes are actually present, so a short read here (io.ErrUnexpectedEOF)
+// is never a clean end of stream — it means the stream was cut off
+// mid-header, which must surface as a real error rather than be mistaken
+// for readSnapshotChunks' normal termination condition.
+func decodeChunk(r io.Reader) (*types.SnapshotChunk, error) {
+	header := make([]byte, chunkHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err // io.EOF is expected at end of stream; io.ErrUnexpectedEOF is a truncated stream
+	}
+	if string(header[0:4]) != chunkMagic {
+		return nil, fmt.Errorf("consensus: unrecognized snapshot chunk magic %q", header[0:4])
+	}
+	if version := header[4]; version != chunkFormatBinary {
+		return nil, fmt.Errorf("consensus: unsupported snapshot chunk format version %d", version)
+	}
+
+	seq := binary.BigEndian.Uint64(header[5:13])
+	flags := header[13]
+	metadataLen := binary.BigEndian.Uint32(header[14:18])
+	filenameLen := binary.BigEndian.Uint16(header[18:20])
+	offset := binary.BigEndian.Uint64(header[20:28])
+	dataLen := binary.BigEndian.Uint32(header[28:32])
+	wantCRC := binary.BigEndian.Uint32(header[32:36])
+
+	payloadLen := int(metadataLen) + int(filenameLen) + int(dataLen)
+	bufPtr := chunkPayloadPool.Get().(*[]byte)
+	payload := *bufPtr
+	if cap(payload) < payloadLen {
+		payload = make([]byte, payloadLen)
+	} else {
+		payload = payload[:payloadLen]
+	}
+	defer func() {
+		*bufPtr = payload[:0]
+		chunkPayloadPool.Put(bufPtr)
+	}()
+
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("failed to read chunk payload: %w", err)
+	}
+	if gotCRC := crc32.Checksum(payload, chunkCRCTable); gotCRC != wantCRC {
+		return nil, fmt.Errorf("%w: want %08x, got %08x", ErrChunkChecksumMismatch, wantCRC, gotCRC)
+	}
+
+	chunk := &types.SnapshotChunk{
+		Sequence: seq,
+		IsFinal:  flags&chunkFlagIsFinal != 0,
+	}
+
+	pos := 0
+	if metadataLen > 0 {
+		var meta types.SnapshotMetadata
+		if err := json.Unmarshal(payload[pos:pos+int(metadataLen)], &meta); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal chunk metadata: %w", err)
+		}
+		chunk.Metadata = &meta
+		pos += int(metadataLen)
+	}
+
+	if filenameLen > 0 || dataLen > 0 {
+		filename := string(payload[pos : pos+int(filenameLen)])
+		pos += int(filenameLen)
+
+		// payload is pooled and reused by the next decodeChunk call, so
+		// Data must be copied into a slice the caller owns.
+		data := make([]byte, dataLen)
+		copy(data, payload[pos:pos+int(dataLen)])
+
+		chunk.FileChunk = &types.FileChunk{
+			FileName:    filename,
+			Offset:      offset,
+			Data:        data,
+			IsLastChunk: flags&chunkFlagIsLastChunk != 0,
+			Checksum:    fmt.Sprintf("%08x", crc32.Checksum(data, chunkCRCTable)),
+		}
+	}
+
+	return chunk, nil
+}
+
+// readLegacyChunk reads a chunk in the [length][crc32c][JSON] format used
+// before the binary chunk format, so snapshots written by older versions
+// of this node remain readable.
+func readLegacyChunk(r io.Reader) (*types.SnapshotChunk, error) {
 	var length uint32
 	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
 		return nil, err // io.EOF is expected at end of stream
 	}
 
-	// Read chunk data
+	var wantCRC uint32
+	if err := binary.Read(r, binary.BigEndian, &wantCRC); err != nil {
+		return nil, fmt.Errorf("failed to read chunk checksum: %w", err)
+	}
+
 	data := make([]byte, length)
 	if _, err := io.ReadFull(r, data); err != nil {
 		return nil, fmt.Errorf("failed to read chunk data: %w", err)
 	}
 
-	// Unmarshal JSON to SnapshotChunk
+	if gotCRC := crc32.Checksum(data, chunkCRCTable); gotCRC != wantCRC {
+		return nil, fmt.Errorf("%w: want %08x, got %08x", ErrChunkChecksumMismatch, wantCRC, gotCRC)
+	}
+
 	var chunk types.SnapshotChunk
 	if err := json.Unmarshal(data, &chunk); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal chunk: %w", err)
@@ -163,6 +761,24 @@ func readChunk(r io.Reader) (*types.SnapshotChunk, error) {
 	return &chunk, nil
 }
 
+// readChunk reads a single chunk from br, dispatching to decodeChunk or
+// readLegacyChunk depending on whether the next 4 bytes are the binary
+// format's magic, without consuming them from the stream either way.
+func readChunk(br *bufio.Reader) (*types.SnapshotChunk, error) {
+	magic, err := br.Peek(4)
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+
+	if string(magic) == chunkMagic {
+		return decodeChunk(br)
+	}
+	return readLegacyChunk(br)
+}
+
 // writeSnapshotToSink writes all snapshot chunks from a collector to a Raft snapshot sink.
 func writeSnapshotToSink(sink raft.SnapshotSink, collector *types.SnapshotCollector) error {
 	for i, chunk := range collector.Chunks {
@@ -173,15 +789,17 @@ func writeSnapshotToSink(sink raft.SnapshotSink, collector *types.SnapshotCollec
 	return nil
 }
 
-// readSnapshotChunks reads all snapshot chunks from an io.ReadCloser.
-// The reader is closed before returning.
+// readSnapshotChunks reads all snapshot chunks from an io.ReadCloser,
+// transparently handling both the current binary format and the legacy
+// JSON-framed format. The reader is closed before returning.
 func readSnapshotChunks(rc io.ReadCloser) ([]*types.SnapshotChunk, error) {
 	defer rc.Close()
 
+	br := bufio.NewReader(rc)
 	chunks := make([]*types.SnapshotChunk, 0)
 
 	for {
-		chunk, err := readChunk(rc)
+		chunk, err := readChunk(br)
 		if err == io.EOF {
 			// Expected end of stream
 			break
@@ -0,0 +1,115 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	clusterclient "github.com/uttom-akash/vector-xlite/distributed/cluster/pkg/client"
+)
+
+// joinInitialBackoff and joinMaxBackoff bound the exponential backoff
+// between JoinCluster attempts, analogous to etcd's retry-with-backoff
+// when a new member can't yet reach the cluster.
+const (
+	joinInitialBackoff = 500 * time.Millisecond
+	joinMaxBackoff     = 10 * time.Second
+	joinMaxAttempts    = 10
+)
+
+// JoinCluster discovers the current leader by dialing seeds in turn and
+// calling GetClusterInfo, then requests voter membership for (selfID,
+// selfAddr) via the leader's JoinCluster RPC, retrying with exponential
+// backoff if the leader is unreachable or not yet elected. This is the
+// self-serve counterpart to an operator manually calling
+// VxRaftNode.AddVoter from another node.
+func JoinCluster(ctx context.Context, seeds []string, selfID, selfAddr string) error {
+	if len(seeds) == 0 {
+		return fmt.Errorf("join cluster: no seed addresses given")
+	}
+
+	backoff := joinInitialBackoff
+	var lastErr error
+	for attempt := 1; attempt <= joinMaxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		leaderAddr, err := discoverLeader(ctx, seeds)
+		if err != nil {
+			lastErr = err
+			log.Printf("join cluster: attempt %d: %v", attempt, err)
+		} else if err := requestVoter(ctx, leaderAddr, selfID, selfAddr); err != nil {
+			lastErr = err
+			log.Printf("join cluster: attempt %d: request voter from %s: %v", attempt, leaderAddr, err)
+		} else {
+			log.Printf("join cluster: %s (%s) admitted as voter via leader %s", selfID, selfAddr, leaderAddr)
+			return nil
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if backoff *= 2; backoff > joinMaxBackoff {
+			backoff = joinMaxBackoff
+		}
+	}
+
+	return fmt.Errorf("join cluster: exhausted %d attempts: %w", joinMaxAttempts, lastErr)
+}
+
+// discoverLeader dials each seed in turn until one answers GetClusterInfo
+// with a known leader address, and returns the leader's cluster gRPC
+// address: GetClusterInfo reports the leader's Raft address (port ending
+// in 1), whose ClusterService listens on the same port ending in 2.
+func discoverLeader(ctx context.Context, seeds []string) (string, error) {
+	var lastErr error
+	for _, seed := range seeds {
+		c, err := clusterclient.NewClusterClientSimple(seed)
+		if err != nil {
+			lastErr = fmt.Errorf("dial seed %s: %w", seed, err)
+			continue
+		}
+
+		info, err := c.GetClusterInfo(ctx)
+		c.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("get cluster info from %s: %w", seed, err)
+			continue
+		}
+		if info.LeaderAddr == "" {
+			lastErr = fmt.Errorf("seed %s reports no leader elected yet", seed)
+			continue
+		}
+		if n := len(info.LeaderAddr); info.LeaderAddr[n-1] != '1' {
+			lastErr = fmt.Errorf("seed %s reports leader raft address %s whose port does not end in 1", seed, info.LeaderAddr)
+			continue
+		}
+		return info.LeaderAddr[:len(info.LeaderAddr)-1] + "2", nil
+	}
+	return "", lastErr
+}
+
+// requestVoter asks leaderAddr's ClusterService to add (selfID, selfAddr)
+// as a voter. The leader answers an already-present voter with success, so
+// a rejoin after a crash just succeeds again.
+func requestVoter(ctx context.Context, leaderAddr, selfID, selfAddr string) error {
+	c, err := clusterclient.NewClusterClientSimple(leaderAddr)
+	if err != nil {
+		return fmt.Errorf("dial leader %s: %w", leaderAddr, err)
+	}
+	defer c.Close()
+
+	caps := CurrentCapabilities()
+	resp, err := c.JoinCluster(ctx, selfID, selfAddr, false, int32(caps.ProtocolVersion), caps.Features)
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("leader rejected join: %s", resp.Message)
+	}
+	return nil
+}
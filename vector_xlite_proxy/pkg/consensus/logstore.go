@@ -0,0 +1,52 @@
+package consensus
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+	"github.com/uttom-akash/vector-xlite/vector_xlite_proxy/pkg/consensus/walstore"
+)
+
+// LogStoreFactory opens the raft.LogStore a node uses for its Raft log,
+// so NewRaftNode isn't hard-wired to a single storage engine. StableStore
+// (small key/value state like CurrentTerm) stays BoltDB-backed regardless
+// of which LogStoreFactory is chosen, since it isn't the component under
+// log-growth pressure.
+type LogStoreFactory interface {
+	// Open returns the log store rooted at dataDir, creating it if
+	// necessary.
+	Open(dataDir string) (raft.LogStore, error)
+}
+
+// BoltLogStoreFactory opens a BoltDB-backed log store, the prior
+// hard-coded behavior. Simple and battle-tested, but its mmap'd
+// single-file layout makes truncation and compaction expensive once the
+// log grows past a few GB.
+type BoltLogStoreFactory struct{}
+
+// Open implements LogStoreFactory.
+func (BoltLogStoreFactory) Open(dataDir string) (raft.LogStore, error) {
+	path := filepath.Join(dataDir, "raft-log.db")
+	store, err := raftboltdb.NewBoltStore(path)
+	if err != nil {
+		return nil, fmt.Errorf("open bolt log store: %w", err)
+	}
+	return store, nil
+}
+
+// WALLogStoreFactory opens a segmented-file log store (see the walstore
+// package): fixed-size segments with an index file each, so truncating a
+// compacted prefix is an O(1) directory operation instead of BoltDB's
+// in-place page management.
+type WALLogStoreFactory struct{}
+
+// Open implements LogStoreFactory.
+func (WALLogStoreFactory) Open(dataDir string) (raft.LogStore, error) {
+	store, err := walstore.NewStore(filepath.Join(dataDir, "wal"))
+	if err != nil {
+		return nil, fmt.Errorf("open wal log store: %w", err)
+	}
+	return store, nil
+}
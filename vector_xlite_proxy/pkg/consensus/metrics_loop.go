@@ -0,0 +1,29 @@
+package consensus
+
+import (
+	"context"
+	"time"
+
+	"github.com/uttom-akash/vector-xlite/vector_xlite_proxy/pkg/metrics"
+)
+
+// RunMetricsLoop periodically reports this node's Raft state and
+// follower lag (LastIndex - LastApplied) to collectors, until ctx is
+// canceled. It's the only piece of Raft health metrics that isn't
+// naturally observed at a call site like Apply or Restore, so main.go
+// runs it as a background goroutine alongside the node.
+func (n *VxRaftNode) RunMetricsLoop(ctx context.Context, collectors *metrics.Collectors, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		collectors.SetRaftState(n.id, n.State())
+		collectors.SetFollowerLag(float64(n.LastIndex() - n.LastApplied()))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -12,8 +12,13 @@ import (
 	"github.com/hashicorp/raft"
 	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
 	client "github.com/uttom-akash/vector-xlite/go_grpc_client/client"
+	"github.com/uttom-akash/vector-xlite/vector_xlite_proxy/pkg/snapshots"
 )
 
+// snapshotManagerKeep is how many of the most recent VxFSM-level snapshots
+// a node retains, independent of hashicorp/raft's own snapshot retention.
+const snapshotManagerKeep = 2
+
 type VxRaftNode struct {
 	id        string
 	bindAddr  string
@@ -21,16 +26,56 @@ type VxRaftNode struct {
 	transport *raft.NetworkTransport
 	Fsm       *VxFSM // Exported for access to VectorClient
 	dataDir   string
+
+	// ReadForwarder, if set, lets ReadIndex serve a follower's read-index
+	// query by forwarding it to the current leader over the cluster gRPC
+	// API. Nil on a node that doesn't have a cluster client wired in, in
+	// which case ReadIndex fails on followers instead of forwarding.
+	ReadForwarder ReadIndexForwarder
+}
+
+// ReadIndexForwarder forwards a read-index query to the leader reachable at
+// leaderClusterAddr, returning the index the follower must locally apply up
+// to before serving a linearizable read. Injected from outside this package
+// so consensus doesn't need to import a cluster gRPC client.
+type ReadIndexForwarder func(ctx context.Context, leaderClusterAddr string) (uint64, error)
+
+// RaftNodeOptions configures hashicorp/raft's snapshot triggering. Zero
+// values fall back to raft.DefaultConfig()'s own defaults.
+type RaftNodeOptions struct {
+	// SnapshotInterval is how often Raft checks whether a snapshot is due.
+	SnapshotInterval time.Duration
+	// SnapshotThreshold is how many log entries must accumulate since the
+	// last snapshot before Raft triggers one, similar to etcd's
+	// SnapCount-based triggering.
+	SnapshotThreshold uint64
+	// TrailingLogs is how many log entries Raft retains after taking a
+	// snapshot, so a follower that's only slightly behind can still catch
+	// up by log replay instead of a full InstallSnapshot.
+	TrailingLogs uint64
+
+	// LogStoreFactory opens the raft.LogStore this node's log is kept in.
+	// Nil defaults to BoltLogStoreFactory{}, the prior hard-coded behavior.
+	LogStoreFactory LogStoreFactory
 }
 
 // NewRaftNode creates and configures a Raft node (but does not bootstrap).
-func NewRaftNode(id, bindAddr, vectorAddr, dataDir string, isInitial bool) (*VxRaftNode, error) {
+func NewRaftNode(id, bindAddr, vectorAddr, dataDir string, isInitial bool, opts RaftNodeOptions) (*VxRaftNode, error) {
 	if err := os.MkdirAll(dataDir, 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
 
 	config := raft.DefaultConfig()
 	config.LocalID = raft.ServerID(id)
+	if opts.SnapshotInterval > 0 {
+		config.SnapshotInterval = opts.SnapshotInterval
+	}
+	if opts.SnapshotThreshold > 0 {
+		config.SnapshotThreshold = opts.SnapshotThreshold
+	}
+	if opts.TrailingLogs > 0 {
+		config.TrailingLogs = opts.TrailingLogs
+	}
 
 	ctx := context.Background()
 	vectorClient, err := client.NewClient(ctx, vectorAddr, 5*time.Second)
@@ -38,16 +83,24 @@ func NewRaftNode(id, bindAddr, vectorAddr, dataDir string, isInitial bool) (*VxR
 		return nil, fmt.Errorf("failed to connect to VectorXLite at %s: %w", vectorAddr, err)
 	}
 
-	fsm := &VxFSM{VectorClient: vectorClient}
+	snapshotStoreDir := filepath.Join(dataDir, "vx-snapshots")
+	snapshotManager := snapshots.NewManager(snapshots.NewFileStore(snapshotStoreDir), snapshotManagerKeep)
 
-	// Set up BoltDB-backed stores
-	logStorePath := filepath.Join(dataDir, "raft-log.db")
-	stableStorePath := filepath.Join(dataDir, "raft-stable.db")
+	fsm := &VxFSM{VectorClient: vectorClient, SnapshotManager: snapshotManager, capabilities: newCapabilitiesTable(), ReadIndexWaiter: NewReadIndexWaiter(), WatchHub: NewWatchHub()}
 
-	logStore, err := raftboltdb.NewBoltStore(logStorePath)
+	logStoreFactory := opts.LogStoreFactory
+	if logStoreFactory == nil {
+		logStoreFactory = BoltLogStoreFactory{}
+	}
+	logStore, err := logStoreFactory.Open(dataDir)
 	if err != nil {
-		return nil, fmt.Errorf("NewBoltStore log: %w", err)
+		return nil, fmt.Errorf("open log store: %w", err)
 	}
+
+	// StableStore (small key/value state like CurrentTerm) stays
+	// BoltDB-backed regardless of LogStoreFactory; it isn't under the same
+	// growth pressure the log is.
+	stableStorePath := filepath.Join(dataDir, "raft-stable.db")
 	stableStore, err := raftboltdb.NewBoltStore(stableStorePath)
 	if err != nil {
 		return nil, fmt.Errorf("NewBoltStore stable: %w", err)
@@ -153,6 +206,35 @@ func (n *VxRaftNode) RemoveServer(id raft.ServerID, prevIndex uint64, timeout ti
 	return f
 }
 
+// AddNonvoter adds a node to the cluster as a non-voting learner: it
+// receives log replication but doesn't count toward quorum, so it can
+// catch up on a large snapshot without risking the cluster's availability
+// (must be called on leader).
+func (n *VxRaftNode) AddNonvoter(id raft.ServerID, addr raft.ServerAddress, prevIndex uint64, timeout time.Duration) raft.IndexFuture {
+	log.Printf("[%s] adding nonvoter: id=%s, addr=%s", n.id, id, addr)
+	f := n.raft.AddNonvoter(raft.ServerID(id), raft.ServerAddress(addr), 0, timeout)
+	return f
+}
+
+// DemoteVoter demotes a voting server to a non-voter in place, the first
+// step of the two-step demote+remove dance callers use to shrink the
+// cluster without a moment where quorum is computed against a server
+// that's about to disappear (must be called on leader).
+func (n *VxRaftNode) DemoteVoter(id raft.ServerID, prevIndex uint64, timeout time.Duration) raft.IndexFuture {
+	log.Printf("[%s] demoting voter: id=%s", n.id, id)
+	f := n.raft.DemoteVoter(raft.ServerID(id), 0, timeout)
+	return f
+}
+
+// LeadershipTransfer asks this node (which must currently be leader) to
+// hand off leadership to the server identified by id/address, so an
+// operator-initiated drain doesn't have to wait out an election timeout
+// for the rest of the cluster to notice this node is stepping down.
+func (n *VxRaftNode) LeadershipTransfer(id raft.ServerID, address raft.ServerAddress) raft.Future {
+	log.Printf("[%s] transferring leadership to: id=%s, addr=%s", n.id, id, address)
+	return n.raft.LeadershipTransferToServer(id, address)
+}
+
 // GetConfiguration returns the current cluster configuration.
 func (n *VxRaftNode) GetConfiguration() raft.ConfigurationFuture {
 	f := n.raft.GetConfiguration()
@@ -179,3 +261,41 @@ func (n *VxRaftNode) State() raft.RaftState {
 func (n *VxRaftNode) Leader() raft.ServerAddress {
 	return n.raft.Leader()
 }
+
+// LastApplied returns the Raft log index this node's FSM has applied
+// through, for ReadIndex callers waiting to catch up to a leader-returned
+// index.
+func (n *VxRaftNode) LastApplied() uint64 {
+	return n.Fsm.LastApplied()
+}
+
+// LastIndex returns the last index in this node's Raft log, for
+// computing follower lag (LastIndex - LastApplied) alongside LastApplied.
+func (n *VxRaftNode) LastIndex() uint64 {
+	return n.raft.LastIndex()
+}
+
+// ReadIndex returns the Raft log index a linearizable read must wait for
+// locally before it's safe to serve, using the etcd/raft ReadIndex
+// technique: on the leader, it confirms current leadership with a
+// heartbeat round (VerifyLeader) and returns the last log index, avoiding
+// a log write just to serve a read. On a follower, it forwards the query
+// to the leader via ReadForwarder and returns whatever index the leader
+// reports.
+func (n *VxRaftNode) ReadIndex(ctx context.Context) (uint64, error) {
+	if n.raft.State() == raft.Leader {
+		if err := n.raft.VerifyLeader().Error(); err != nil {
+			return 0, fmt.Errorf("verify leadership: %w", err)
+		}
+		return n.raft.LastIndex(), nil
+	}
+
+	if n.ReadForwarder == nil {
+		return 0, fmt.Errorf("not leader and no ReadIndexForwarder configured")
+	}
+	leaderAddr := n.raft.Leader()
+	if leaderAddr == "" {
+		return 0, fmt.Errorf("no leader available")
+	}
+	return n.ReadForwarder(ctx, string(leaderAddr))
+}
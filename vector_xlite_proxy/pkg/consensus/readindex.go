@@ -0,0 +1,63 @@
+package consensus
+
+import (
+	"context"
+	"sync"
+)
+
+// ReadIndexWaiter lets a caller block until VxFSM.Apply has processed a
+// given Raft log index, instead of polling LastApplied on a timer.
+// VxFSM.Apply signals it on every entry; Wait blocks until that index (or
+// a later one) has been signaled, or ctx is done - the piece a follower's
+// Linearizable Search needs to honor a ReadIndex barrier without busy-
+// waiting.
+type ReadIndexWaiter struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	applied uint64
+}
+
+// NewReadIndexWaiter creates a waiter with nothing yet signaled.
+func NewReadIndexWaiter() *ReadIndexWaiter {
+	w := &ReadIndexWaiter{}
+	w.cond = sync.NewCond(&w.mu)
+	return w
+}
+
+// Signal records that index has been applied and wakes every Wait call
+// blocked on it or an earlier index. Indexes must only ever advance;
+// signaling one lower than the last is a no-op rather than an error, so
+// a caller doesn't need to serialize calls against each other.
+func (w *ReadIndexWaiter) Signal(index uint64) {
+	w.mu.Lock()
+	if index > w.applied {
+		w.applied = index
+	}
+	w.mu.Unlock()
+	w.cond.Broadcast()
+}
+
+// Wait blocks until index has been applied or ctx is done.
+func (w *ReadIndexWaiter) Wait(ctx context.Context, index uint64) error {
+	// sync.Cond has no ctx-aware wait, so a goroutine bridges ctx.Done()
+	// into a Broadcast that wakes the loop below up to notice ctx expired.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.cond.Broadcast()
+		case <-stop:
+		}
+	}()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for w.applied < index {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		w.cond.Wait()
+	}
+	return nil
+}
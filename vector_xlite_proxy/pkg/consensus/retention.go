@@ -0,0 +1,91 @@
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+// RetentionSweeper periodically deletes vectors that have aged past their
+// collection's retention policy. It only issues deletes while the local
+// node is the Raft leader so delete commands are replicated exactly once
+// per expired row.
+type RetentionSweeper struct {
+	node     *VxRaftNode
+	interval time.Duration
+	stopCh   chan struct{}
+}
+
+// NewRetentionSweeper creates a sweeper that checks for expired vectors
+// every interval.
+func NewRetentionSweeper(node *VxRaftNode, interval time.Duration) *RetentionSweeper {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &RetentionSweeper{
+		node:     node,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Run blocks, sweeping expired vectors until Stop is called.
+func (s *RetentionSweeper) Run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if s.node.State() != raft.Leader {
+				continue
+			}
+			if err := s.sweepOnce(); err != nil {
+				log.Printf("[%s] retention sweep failed: %v", s.node.id, err)
+			}
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// Stop terminates the sweeper's background loop.
+func (s *RetentionSweeper) Stop() {
+	close(s.stopCh)
+}
+
+// sweepOnce finds rows older than their collection's retention duration and
+// replicates a delete-by-rowid command for each expired id so followers
+// stay consistent.
+func (s *RetentionSweeper) sweepOnce() error {
+	ctx := context.Background()
+
+	expired, err := s.node.Fsm.VectorClient.FindExpiredRows(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range expired {
+		payload, err := json.Marshal(DeletePayload{
+			CollectionName: row.CollectionName,
+			ID:             row.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		cmd, err := json.Marshal(Command{Type: CmdDelete, Payload: payload})
+		if err != nil {
+			return err
+		}
+
+		if err := s.node.Apply(cmd, 5*time.Second).Error(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
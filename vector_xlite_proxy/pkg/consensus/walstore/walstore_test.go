@@ -0,0 +1,180 @@
+package walstore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+func testLog(index uint64) *raft.Log {
+	return &raft.Log{
+		Index:      index,
+		Term:       1,
+		Type:       raft.LogCommand,
+		Data:       []byte(fmt.Sprintf("data-%d", index)),
+		AppendedAt: time.Unix(0, int64(index)*int64(time.Second)),
+	}
+}
+
+// TestStoreAndGetLog verifies a stored entry reads back with every field
+// intact, including AppendedAt, which toRaftLog previously dropped on the
+// read path despite walRecord carrying it.
+func TestStoreAndGetLog(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	want := testLog(1)
+	if err := store.StoreLog(want); err != nil {
+		t.Fatalf("StoreLog: %v", err)
+	}
+
+	var got raft.Log
+	if err := store.GetLog(1, &got); err != nil {
+		t.Fatalf("GetLog: %v", err)
+	}
+
+	if got.Index != want.Index || got.Term != want.Term || got.Type != want.Type || string(got.Data) != string(want.Data) {
+		t.Fatalf("round-tripped log differs: want %+v, got %+v", want, got)
+	}
+	if !got.AppendedAt.Equal(want.AppendedAt) {
+		t.Errorf("AppendedAt not round-tripped: want %v, got %v", want.AppendedAt, got.AppendedAt)
+	}
+}
+
+// TestNonIndexOneStart covers a learner/follower that bootstraps via
+// snapshot install and starts appending at an index far from 1: the
+// first segment's on-disk filename must match the firstIndex actually
+// written to it, or a restart re-derives the wrong firstIndex from the
+// filename and every subsequent read computes the wrong offset.
+func TestNonIndexOneStart(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	const start = 5000
+	for i := uint64(0); i < 10; i++ {
+		if err := store.StoreLog(testLog(start + i)); err != nil {
+			t.Fatalf("StoreLog(%d): %v", start+i, err)
+		}
+	}
+	store.Close()
+
+	reopened, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore (reopen): %v", err)
+	}
+	defer reopened.Close()
+
+	first, err := reopened.FirstIndex()
+	if err != nil {
+		t.Fatalf("FirstIndex: %v", err)
+	}
+	if first != start {
+		t.Fatalf("expected FirstIndex %d after reopen, got %d", start, first)
+	}
+
+	last, err := reopened.LastIndex()
+	if err != nil {
+		t.Fatalf("LastIndex: %v", err)
+	}
+	if last != start+9 {
+		t.Fatalf("expected LastIndex %d after reopen, got %d", start+9, last)
+	}
+
+	var got raft.Log
+	if err := reopened.GetLog(start+9, &got); err != nil {
+		t.Fatalf("GetLog(%d) after reopen: %v", start+9, err)
+	}
+	if got.Index != start+9 {
+		t.Fatalf("expected to read back index %d, got %d", start+9, got.Index)
+	}
+}
+
+// TestChecksumMismatchDetected verifies a corrupted record is rejected at
+// read time rather than silently returned.
+func TestChecksumMismatchDetected(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if err := store.StoreLog(testLog(1)); err != nil {
+		t.Fatalf("StoreLog: %v", err)
+	}
+	store.Close()
+
+	dataPath, _ := segmentPaths(dir, 1)
+	f, err := os.OpenFile(dataPath, os.O_RDWR, 0o644)
+	if err != nil {
+		t.Fatalf("open data file: %v", err)
+	}
+	// Flip a byte just past the record header, inside the JSON payload
+	// the checksum covers.
+	if _, err := f.WriteAt([]byte{0xff}, recordHeaderSize); err != nil {
+		t.Fatalf("corrupt data file: %v", err)
+	}
+	f.Close()
+
+	reopened, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore (reopen): %v", err)
+	}
+	defer reopened.Close()
+
+	var got raft.Log
+	err = reopened.GetLog(1, &got)
+	if err == nil {
+		t.Fatal("expected GetLog to fail on corrupted record, got nil error")
+	}
+}
+
+// TestDeleteRange verifies whole-segment-aligned deletion removes the
+// covered entries and updates FirstIndex, leaving later entries readable.
+func TestDeleteRange(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer store.Close()
+
+	for i := uint64(1); i <= 5; i++ {
+		if err := store.StoreLog(testLog(i)); err != nil {
+			t.Fatalf("StoreLog(%d): %v", i, err)
+		}
+	}
+
+	// The active segment is never deleted by DeleteRange (see its doc
+	// comment), so roll over to a fresh segment before compacting the
+	// first one away.
+	seg, err := store.createSegment(6)
+	if err != nil {
+		t.Fatalf("createSegment: %v", err)
+	}
+	store.segments = append(store.segments, seg)
+	if err := store.StoreLog(testLog(6)); err != nil {
+		t.Fatalf("StoreLog(6): %v", err)
+	}
+
+	if err := store.DeleteRange(1, 5); err != nil {
+		t.Fatalf("DeleteRange: %v", err)
+	}
+
+	var got raft.Log
+	if err := store.GetLog(3, &got); !errors.Is(err, raft.ErrLogNotFound) {
+		t.Fatalf("expected ErrLogNotFound for deleted index 3, got: %v", err)
+	}
+	if err := store.GetLog(6, &got); err != nil {
+		t.Fatalf("GetLog(6) after DeleteRange: %v", err)
+	}
+}
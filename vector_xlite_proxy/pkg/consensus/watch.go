@@ -0,0 +1,106 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// defaultWatchRingSize bounds how many WatchEvents a WatchHub retains
+// before evicting the oldest, the same way etcd's watch implementation
+// bounds history instead of keeping it forever.
+const defaultWatchRingSize = 10000
+
+// ErrWatchCompacted is returned by WatchHub.Since when a watcher asks to
+// resume from a revision older than anything still retained, mirroring
+// etcd's ErrCompacted: the caller must re-list current state and restart
+// its watch from a fresher revision instead of silently missing events.
+var ErrWatchCompacted = errors.New("consensus: requested start revision has been compacted")
+
+// WatchEvent is one committed change VxFSM.Apply publishes for Watch
+// subscribers, carrying just enough for a subscriber to filter by
+// collection or embedding-space region without re-decoding the original
+// Command payload.
+type WatchEvent struct {
+	Index          uint64
+	Type           CommandType // CmdCreateCollection, CmdInsert(Batch/BulkInsert), or CmdDelete(Batch)
+	CollectionName string
+	PointID        uint64
+	Vector         []float32
+}
+
+// WatchHub fans committed WatchEvents out to any number of Watch
+// subscribers. Events are retained in a bounded ring so a watcher can
+// resume from a prior revision (StartRevision) instead of only ever
+// tailing new events, the way etcd's watch stream supports resuming from
+// a compacted-but-not-yet-evicted revision.
+type WatchHub struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	cap   int
+	// events holds up to cap retained events, oldest first.
+	events []WatchEvent
+}
+
+// NewWatchHub creates a WatchHub with the default ring size.
+func NewWatchHub() *WatchHub {
+	h := &WatchHub{cap: defaultWatchRingSize}
+	h.cond = sync.NewCond(&h.mu)
+	return h
+}
+
+// Publish appends ev to the ring, evicting the oldest retained event if
+// it's full, and wakes every Watch subscriber blocked in WaitForMore.
+func (h *WatchHub) Publish(ev WatchEvent) {
+	h.mu.Lock()
+	h.events = append(h.events, ev)
+	if len(h.events) > h.cap {
+		h.events = h.events[len(h.events)-h.cap:]
+	}
+	h.mu.Unlock()
+	h.cond.Broadcast()
+}
+
+// Since returns every retained event with Index > afterIndex, oldest
+// first. afterIndex of 0 returns everything still retained. Returns
+// ErrWatchCompacted if afterIndex is older than the oldest retained
+// event, meaning events between the two have already been evicted.
+func (h *WatchHub) Since(afterIndex uint64) ([]WatchEvent, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if afterIndex != 0 && len(h.events) > 0 && afterIndex < h.events[0].Index-1 {
+		return nil, ErrWatchCompacted
+	}
+
+	var out []WatchEvent
+	for _, ev := range h.events {
+		if ev.Index > afterIndex {
+			out = append(out, ev)
+		}
+	}
+	return out, nil
+}
+
+// WaitForMore blocks until an event newer than afterIndex has been
+// published, or ctx is done.
+func (h *WatchHub) WaitForMore(ctx context.Context, afterIndex uint64) {
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			h.cond.Broadcast()
+		case <-stop:
+		}
+	}()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ctx.Err() == nil {
+		if len(h.events) > 0 && h.events[len(h.events)-1].Index > afterIndex {
+			return
+		}
+		h.cond.Wait()
+	}
+}
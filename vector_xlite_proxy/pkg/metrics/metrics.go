@@ -0,0 +1,167 @@
+// Package metrics registers the Prometheus collectors a VectorXLite
+// cluster node exposes on its /metrics endpoint: Raft health (state,
+// apply latency, follower lag, snapshot install cost) and cluster gRPC
+// traffic (search/insert QPS and latency), giving operators visibility
+// comparable to etcd's server metrics.
+package metrics
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+const namespace = "vectorxlite"
+
+// raftStates lists every raft.RaftState Collectors.SetRaftState knows
+// how to report, so it can zero the ones a node isn't currently in.
+var raftStates = []raft.RaftState{raft.Follower, raft.Candidate, raft.Leader, raft.Shutdown}
+
+// Collectors bundles every Prometheus collector a node registers, so
+// callers wire them all up with one NewCollectors call instead of
+// constructing and registering each metric by hand.
+type Collectors struct {
+	RaftState        *prometheus.GaugeVec
+	ApplyLatency     *prometheus.HistogramVec
+	FollowerLag      prometheus.Gauge
+	SnapshotDuration prometheus.Histogram
+	SnapshotBytes    prometheus.Histogram
+	RPCLatency       *prometheus.HistogramVec
+	RPCTotal         *prometheus.CounterVec
+}
+
+// NewCollectors creates every collector and registers them with reg.
+// Pass a dedicated prometheus.NewRegistry() (what cmd/node's /metrics
+// handler does) rather than prometheus.DefaultRegisterer, so running
+// multiple nodes in one process (as the integration tests do) doesn't
+// panic on duplicate registration.
+func NewCollectors(reg prometheus.Registerer) *Collectors {
+	c := &Collectors{
+		RaftState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "raft",
+			Name:      "state",
+			Help:      "1 if this node is currently in the labeled Raft state, else 0.",
+		}, []string{"node_id", "state"}),
+
+		ApplyLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "raft",
+			Name:      "apply_latency_seconds",
+			Help:      "Latency of VxFSM.Apply, broken down by Command.Type.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"command_type"}),
+
+		FollowerLag: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "raft",
+			Name:      "follower_lag",
+			Help:      "raft.LastIndex() minus this node's VxFSM.LastApplied().",
+		}),
+
+		SnapshotDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "raft",
+			Name:      "snapshot_install_duration_seconds",
+			Help:      "Time spent in VxFSM.Restore installing a Raft snapshot.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+
+		SnapshotBytes: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "raft",
+			Name:      "snapshot_install_bytes",
+			Help:      "Size of installed Raft snapshots.",
+			Buckets:   prometheus.ExponentialBuckets(1024, 4, 10),
+		}),
+
+		RPCLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "rpc_latency_seconds",
+			Help:      "ClusterService handler latency by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+
+		RPCTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cluster",
+			Name:      "rpc_total",
+			Help:      "ClusterService calls by method and result, for QPS via rate().",
+		}, []string{"method", "result"}),
+	}
+
+	reg.MustRegister(
+		c.RaftState,
+		c.ApplyLatency,
+		c.FollowerLag,
+		c.SnapshotDuration,
+		c.SnapshotBytes,
+		c.RPCLatency,
+		c.RPCTotal,
+	)
+	return c
+}
+
+// SetRaftState records nodeID's current Raft state, zeroing every other
+// known state so exactly one state gauge reads 1 for a given node_id at
+// a time.
+func (c *Collectors) SetRaftState(nodeID string, state raft.RaftState) {
+	for _, s := range raftStates {
+		v := 0.0
+		if s == state {
+			v = 1
+		}
+		c.RaftState.WithLabelValues(nodeID, s.String()).Set(v)
+	}
+}
+
+// ObserveApply records how long a VxFSM.Apply call for the given
+// command type took.
+func (c *Collectors) ObserveApply(commandType string, d time.Duration) {
+	c.ApplyLatency.WithLabelValues(commandType).Observe(d.Seconds())
+}
+
+// SetFollowerLag records raft.LastIndex() - VxFSM.LastApplied() for this
+// node. Reads 0 on a healthy leader.
+func (c *Collectors) SetFollowerLag(lag float64) {
+	c.FollowerLag.Set(lag)
+}
+
+// ObserveSnapshotInstall records how long VxFSM.Restore took and how
+// many chunk bytes the installed snapshot carried.
+func (c *Collectors) ObserveSnapshotInstall(d time.Duration, bytes int) {
+	c.SnapshotDuration.Observe(d.Seconds())
+	c.SnapshotBytes.Observe(float64(bytes))
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor recording
+// RPCLatency/RPCTotal for every call, the source of Search/Insert QPS
+// and latency on the Grafana dashboard. It composes with
+// server.LeaderRedirectInterceptor and server.LoggingInterceptor via
+// grpc.ChainUnaryInterceptor the same way they do.
+func (c *Collectors) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		method := path.Base(info.FullMethod)
+		c.RPCLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		result := "success"
+		if err != nil {
+			result = "error"
+		}
+		c.RPCTotal.WithLabelValues(method, result).Inc()
+
+		return resp, err
+	}
+}
@@ -0,0 +1,50 @@
+package snapshots
+
+import (
+	"log"
+	"time"
+)
+
+// Compactor periodically prunes a Manager down to its retention policy in
+// the background, so Raft snapshots accumulated over the node's lifetime
+// don't grow the data directory without bound.
+type Compactor struct {
+	manager  *Manager
+	interval time.Duration
+	stopCh   chan struct{}
+}
+
+// NewCompactor creates a Compactor that prunes manager every interval.
+// interval <= 0 defaults to 10 minutes.
+func NewCompactor(manager *Manager, interval time.Duration) *Compactor {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	return &Compactor{
+		manager:  manager,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Run blocks, pruning on each tick until Stop is called.
+func (c *Compactor) Run() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.manager.Prune(); err != nil {
+				log.Printf("snapshots: background prune failed: %v", err)
+			}
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// Stop terminates the compactor's background loop.
+func (c *Compactor) Stop() {
+	close(c.stopCh)
+}
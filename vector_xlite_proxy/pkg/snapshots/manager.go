@@ -0,0 +1,183 @@
+// Package snapshots sits between the Raft FSM and types.SnapshotCollector,
+// giving a node a durable, multi-snapshot lifecycle instead of the FSM
+// exporting a fresh snapshot on demand and forgetting it immediately after.
+package snapshots
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	types "github.com/uttom-akash/vector-xlite/go_grpc_client/types"
+)
+
+// Format is an on-disk snapshot format tag, stored as
+// types.SnapshotMetadata.Version. Bumping CurrentFormat and registering a
+// Converter from the old format lets Load migrate older snapshots
+// transparently.
+type Format uint32
+
+// CurrentFormat is the format written by Manager.Create.
+const CurrentFormat Format = 1
+
+// Converter upgrades a collector stored under an older Format to
+// CurrentFormat.
+type Converter func(collector *types.SnapshotCollector) (*types.SnapshotCollector, error)
+
+var (
+	convertersMu sync.RWMutex
+	converters   = map[Format]Converter{}
+)
+
+// RegisterConverter registers the converter Load uses to migrate a
+// snapshot stored under from up to CurrentFormat.
+func RegisterConverter(from Format, fn Converter) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	converters[from] = fn
+}
+
+// HasConverter reports whether a converter is registered for format,
+// i.e. whether Load can deal with it at all.
+func HasConverter(format Format) bool {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	_, ok := converters[format]
+	return ok
+}
+
+func converterFor(format Format) (Converter, bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	fn, ok := converters[format]
+	return fn, ok
+}
+
+// ErrUnknownFormat is returned when a stored snapshot's format is neither
+// CurrentFormat nor has a registered converter.
+type ErrUnknownFormat struct {
+	Format Format
+}
+
+func (e *ErrUnknownFormat) Error() string {
+	return fmt.Sprintf("snapshots: unknown format %d", e.Format)
+}
+
+// Info is a stored snapshot's metadata entry, keyed by (Height, Format).
+type Info struct {
+	Height    uint64
+	Format    Format
+	Chunks    int
+	Checksums []string
+	CreatedAt int64
+}
+
+// Store persists snapshot data and metadata. The default implementation,
+// FileStore, writes under a node's data directory.
+type Store interface {
+	// Save writes collector under (height, format) and returns the Info recorded.
+	Save(height uint64, format Format, collector *types.SnapshotCollector) (*Info, error)
+	// Load reads back the collector stored at (height, format).
+	Load(height uint64, format Format) (*types.SnapshotCollector, error)
+	// List returns every stored Info, in no particular order.
+	List() ([]*Info, error)
+	// Delete removes the snapshot stored at (height, format).
+	Delete(height uint64, format Format) error
+}
+
+// Handle identifies a stored snapshot without requiring the caller to hold
+// its data in memory.
+type Handle struct {
+	Info *Info
+}
+
+// Manager is the single entry point a VxFSM uses for snapshot lifecycle:
+// creating, listing, loading, pruning, and restoring.
+type Manager struct {
+	store Store
+	// Keep is how many of the most recent snapshots Prune retains.
+	Keep int
+
+	mu sync.Mutex
+}
+
+// NewManager creates a Manager backed by store, retaining the keep most
+// recent snapshots on Prune. keep <= 0 defaults to 2.
+func NewManager(store Store, keep int) *Manager {
+	if keep <= 0 {
+		keep = 2
+	}
+	return &Manager{store: store, Keep: keep}
+}
+
+// Create persists collector at height under CurrentFormat, returning a
+// Handle rather than requiring the caller keep collector around.
+func (m *Manager) Create(height uint64, collector *types.SnapshotCollector) (*Handle, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	info, err := m.store.Save(height, CurrentFormat, collector)
+	if err != nil {
+		return nil, fmt.Errorf("snapshots: save height %d: %w", height, err)
+	}
+	return &Handle{Info: info}, nil
+}
+
+// List returns every stored snapshot's Info, most recent height first.
+func (m *Manager) List() ([]*Info, error) {
+	infos, err := m.store.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Height > infos[j].Height })
+	return infos, nil
+}
+
+// Load retrieves the full collector for a stored snapshot, migrating it up
+// to CurrentFormat via a registered Converter if it was stored under an
+// older format.
+func (m *Manager) Load(height uint64, format Format) (*types.SnapshotCollector, error) {
+	collector, err := m.store.Load(height, format)
+	if err != nil {
+		return nil, fmt.Errorf("snapshots: load height %d format %d: %w", height, format, err)
+	}
+	if format == CurrentFormat {
+		return collector, nil
+	}
+	convert, ok := converterFor(format)
+	if !ok {
+		return nil, &ErrUnknownFormat{Format: format}
+	}
+	return convert(collector)
+}
+
+// Restore loads the snapshot at (height, format) and hands the resulting
+// collector to restore. Unknown formats are rejected before restore is
+// called, instead of failing partway through.
+func (m *Manager) Restore(height uint64, format Format, restore func(*types.SnapshotCollector) error) error {
+	collector, err := m.Load(height, format)
+	if err != nil {
+		return err
+	}
+	return restore(collector)
+}
+
+// Prune deletes all but the Keep most recent snapshots.
+func (m *Manager) Prune() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	infos, err := m.List()
+	if err != nil {
+		return err
+	}
+	if len(infos) <= m.Keep {
+		return nil
+	}
+	for _, info := range infos[m.Keep:] {
+		if err := m.store.Delete(info.Height, info.Format); err != nil {
+			return fmt.Errorf("snapshots: prune height %d: %w", info.Height, err)
+		}
+	}
+	return nil
+}